@@ -0,0 +1,203 @@
+// Package ast constructs user-defined syntax tree structs from the results
+// of Parsers, filling in their source positions along the way
+package ast
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+type (
+	fieldKind int
+
+	field struct {
+		name   string
+		index  int
+		kind   fieldKind
+		result int
+	}
+)
+
+// Error messages
+const (
+	ErrNotStruct    = "%s is not a struct type"
+	ErrInvalidTag   = "invalid ast tag on field %s: %s"
+	ErrCannotAssign = "cannot assign %T to field %s of %s"
+	ErrFieldType    = "field %s tagged %s must be of type %s, not %s"
+)
+
+const (
+	resultField fieldKind = iota
+	spanField
+	posField
+	endField
+)
+
+const tagName = "ast"
+
+var spanType = reflect.TypeOf(parse.Span{})
+
+// Build returns a new Parser that matches the provided Parser and constructs
+// a new *T from its results. T must be a struct type. Results are assigned
+// to T's exported fields in order of declaration, unless a field is tagged
+// with the index of the result it should receive, such as `ast:"2"`. Fields
+// of type parse.Span, or tagged `ast:"span"`, receive the Span that the
+// Parser matched. Int fields named Pos, or tagged `ast:"pos"`, receive its
+// starting offset, and int fields tagged `ast:"end"` receive its ending
+// offset. Fields tagged `ast:"-"` are ignored. Build panics if T isn't a
+// struct, or if a field's tag is invalid or doesn't suit the field's type.
+// The returned Parser panics if a result can't be assigned to its field,
+// because that reveals a mistake in the Parser, not in the text it matched
+func Build[T any](p parse.Parser) parse.Parser {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	fields, err := fieldsOf(t)
+	if err != nil {
+		panic(err)
+	}
+	return func(i parse.Input) (*parse.Success, *parse.Failure) {
+		s, f := p(i)
+		if f != nil {
+			return nil, f
+		}
+		v := reflect.New(t)
+		span := i.SpanTo(s.Remaining)
+		if err := populate(v.Elem(), fields, s.Result, span); err != nil {
+			panic(err)
+		}
+		return &parse.Success{
+			Result:      v.Interface(),
+			Remaining:   s.Remaining,
+			Diagnostics: s.Diagnostics,
+		}, nil
+	}
+}
+
+func fieldsOf(t reflect.Type) ([]*field, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf(ErrNotStruct, t)
+	}
+	var res []*field
+	next := 0
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		f := &field{name: sf.Name, index: i}
+		switch tag := sf.Tag.Get(tagName); tag {
+		case "-":
+			continue
+		case "span":
+			if sf.Type != spanType {
+				return nil, fieldTypeError(sf, tag, spanType)
+			}
+			f.kind = spanField
+		case "pos", "end":
+			if !isInt(sf.Type) {
+				return nil, fieldTypeError(sf, tag, "int")
+			}
+			f.kind = posField
+			if tag == "end" {
+				f.kind = endField
+			}
+		case "":
+			switch {
+			case sf.Type == spanType:
+				f.kind = spanField
+			case sf.Name == "Pos" && sf.Type.Kind() == reflect.Int:
+				f.kind = posField
+			default:
+				f.result = next
+				next++
+			}
+		default:
+			idx, err := strconv.Atoi(tag)
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf(ErrInvalidTag, sf.Name, tag)
+			}
+			f.result = idx
+		}
+		res = append(res, f)
+	}
+	return res, nil
+}
+
+func fieldTypeError(sf reflect.StructField, tag string, want any) error {
+	return fmt.Errorf(ErrFieldType, sf.Name, tag, want, sf.Type)
+}
+
+func isInt(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func populate(v reflect.Value, fields []*field, r any, span parse.Span) error {
+	results, ok := r.(parse.Results)
+	if !ok {
+		results = parse.Results{r}
+	}
+	for _, f := range fields {
+		fv := v.Field(f.index)
+		switch f.kind {
+		case spanField:
+			fv.Set(reflect.ValueOf(span))
+		case posField:
+			fv.SetInt(int64(span.Start))
+		case endField:
+			fv.SetInt(int64(span.End))
+		default:
+			if f.result >= len(results) {
+				continue
+			}
+			e := results[f.result]
+			if !assign(fv, e) {
+				return fmt.Errorf(ErrCannotAssign, e, f.name, v.Type())
+			}
+		}
+	}
+	return nil
+}
+
+func assign(f reflect.Value, r any) bool {
+	if r == nil {
+		f.Set(reflect.Zero(f.Type()))
+		return true
+	}
+	rv := reflect.ValueOf(r)
+	rt := rv.Type()
+	switch {
+	case rt.AssignableTo(f.Type()):
+		f.Set(rv)
+		return true
+	case rt.Kind() == f.Kind() && rt.ConvertibleTo(f.Type()):
+		f.Set(rv.Convert(f.Type()))
+		return true
+	case f.Kind() == reflect.Slice:
+		return assignSlice(f, r)
+	default:
+		return false
+	}
+}
+
+func assignSlice(f reflect.Value, r any) bool {
+	rv := reflect.ValueOf(r)
+	if rv.Kind() != reflect.Slice {
+		rv = reflect.ValueOf([]any{r})
+	}
+	s := reflect.MakeSlice(f.Type(), rv.Len(), rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		if !assign(s.Index(i), rv.Index(i).Interface()) {
+			return false
+		}
+	}
+	f.Set(s)
+	return true
+}
@@ -0,0 +1,145 @@
+package ast_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/kode4food/kombi/ast"
+	"github.com/kode4food/kombi/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	Name string
+
+	Assign struct {
+		Span  parse.Span
+		Name  Name
+		Value int
+	}
+
+	Call struct {
+		Pos  int
+		End  int    `ast:"end"`
+		Func string `ast:"0"`
+		Args []int  `ast:"1"`
+		Skip string `ast:"-"`
+	}
+)
+
+var (
+	ws     = parse.RegExp(" *").Skip()
+	ident  = parse.RegExp("[a-z]+").Left(ws)
+	number = parse.RegExp("[0-9]+").Left(ws).Map(func(r any) any {
+		res, _ := strconv.Atoi(r.(string))
+		return res
+	})
+)
+
+func symbol(s string) parse.Parser {
+	return parse.String(s).Left(ws).Skip()
+}
+
+func TestBuild(t *testing.T) {
+	as := assert.New(t)
+
+	assign := ast.Build[Assign](
+		ident.Concat(symbol("=")).Concat(number),
+	)
+	s, f := ws.Then(assign).Parse("  answer = 42")
+	as.Nil(f)
+	as.Equal(&Assign{
+		Span:  parse.Span{Start: 2, End: 13},
+		Name:  "answer",
+		Value: 42,
+	}, s.Result)
+}
+
+func TestBuildTagged(t *testing.T) {
+	as := assert.New(t)
+
+	call := ast.Build[Call](
+		ident.Concat(
+			number.SepBy(symbol(",")).Enclosed(symbol("("), symbol(")")).
+				Combine(func(r ...any) any {
+					return r
+				}),
+		),
+	)
+	s, f := call.Parse("max(1, 2, 3) rest")
+	as.Nil(f)
+	as.Equal(&Call{
+		Pos:  0,
+		End:  13,
+		Func: "max",
+		Args: []int{1, 2, 3},
+	}, s.Result)
+
+	s, f = call.Parse("none")
+	as.Nil(s)
+	as.NotNil(f)
+}
+
+func TestBuildErrors(t *testing.T) {
+	as := assert.New(t)
+
+	bad := ast.Build[Assign](number.Concat(number))
+	as.PanicsWithError(
+		fmt.Sprintf(ast.ErrCannotAssign, 1, "Name", "ast_test.Assign"),
+		func() {
+			bad.Or(number).Parse("1 2")
+		},
+	)
+
+	as.PanicsWithError(fmt.Sprintf(ast.ErrNotStruct, "int"), func() {
+		ast.Build[int](number)
+	})
+
+	type badTag struct {
+		Value int `ast:"first"`
+	}
+	as.PanicsWithError(
+		fmt.Sprintf(ast.ErrInvalidTag, "Value", "first"),
+		func() {
+			ast.Build[badTag](number)
+		},
+	)
+
+	type badPos struct {
+		Start string `ast:"pos"`
+	}
+	as.PanicsWithError(
+		fmt.Sprintf(ast.ErrFieldType, "Start", "pos", "int", "string"),
+		func() {
+			ast.Build[badPos](number)
+		},
+	)
+
+	type badEnd struct {
+		End float64 `ast:"end"`
+	}
+	as.PanicsWithError(
+		fmt.Sprintf(ast.ErrFieldType, "End", "end", "int", "float64"),
+		func() {
+			ast.Build[badEnd](number)
+		},
+	)
+
+	type badSpan struct {
+		Where int `ast:"span"`
+	}
+	as.PanicsWithError(
+		fmt.Sprintf(ast.ErrFieldType, "Where", "span", "parse.Span", "int"),
+		func() {
+			ast.Build[badSpan](number)
+		},
+	)
+
+	type narrowEnd struct {
+		Stop int32 `ast:"end"`
+	}
+	s, f := ast.Build[narrowEnd](ws.Then(number)).Parse("  12")
+	as.Nil(f)
+	as.Equal(&narrowEnd{Stop: 4}, s.Result)
+}
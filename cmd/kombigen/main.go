@@ -0,0 +1,150 @@
+// Command kombigen generates standalone Go parsers from grammars. It is
+// intended to be invoked by go generate, either with a PEG grammar file:
+//
+//	//go:generate go run github.com/kode4food/kombi/cmd/kombigen -pkg calc -name Calc -o calc_gen.go calc.peg
+//
+// or with a *grammar.Grammar variable that is built in Go, identified by
+// its package's import path and its name:
+//
+//	//go:generate go run github.com/kode4food/kombi/cmd/kombigen -pkg csv -name Records -o csv_gen.go -var example.com/csv.Grammar
+//
+// A variable is introspected by a temporary program that kombigen builds
+// and runs from within the current directory, so its package must be
+// importable from there
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/kode4food/kombi/generate"
+	"github.com/kode4food/kombi/grammar"
+)
+
+// Error messages
+const (
+	ErrUsage      = "usage: kombigen [flags] (grammar.peg | -var path.Name)"
+	ErrInvalidVar = "invalid grammar variable: %s"
+	ErrIntrospect = "introspecting %s: %w"
+)
+
+var introspect = template.Must(template.New("introspect").Parse(`package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kode4food/kombi/generate"
+	src {{ .Path }}
+)
+
+func main() {
+	res, err := generate.Generate(src.{{ .Var }}, generate.Options{
+		Package: {{ .Package }},
+		Name:    {{ .Name }},
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	_, _ = os.Stdout.Write(res)
+}
+`))
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "kombigen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("kombigen", flag.ContinueOnError)
+	pkg := fs.String("pkg", os.Getenv("GOPACKAGE"), "generated package name")
+	name := fs.String("name", "Grammar", "generated identifier prefix")
+	out := fs.String("o", "", "output file (defaults to stdout)")
+	v := fs.String("var", "", "*grammar.Grammar variable, as path.Name")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	o := generate.Options{
+		Package: *pkg,
+		Name:    *name,
+	}
+	var res []byte
+	var err error
+	switch {
+	case *v != "" && fs.NArg() == 0:
+		res, err = generateVar(*v, o)
+	case *v == "" && fs.NArg() == 1:
+		res, err = generateFile(fs.Arg(0), o)
+	default:
+		return fmt.Errorf(ErrUsage)
+	}
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(res)
+		return err
+	}
+	return os.WriteFile(*out, res, 0644)
+}
+
+func generateFile(path string, o generate.Options) ([]byte, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	g, err := grammar.Parse(string(src))
+	if err != nil {
+		return nil, err
+	}
+	return generate.Generate(g, o)
+}
+
+func generateVar(v string, o generate.Options) ([]byte, error) {
+	idx := strings.LastIndex(v, ".")
+	if idx <= 0 || !token.IsExported(v[idx+1:]) ||
+		!token.IsIdentifier(v[idx+1:]) {
+		return nil, fmt.Errorf(ErrInvalidVar, v)
+	}
+	dir, err := os.MkdirTemp(".", "kombigen")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	var prog bytes.Buffer
+	if err := introspect.Execute(&prog, map[string]string{
+		"Path":    strconv.Quote(v[:idx]),
+		"Var":     v[idx+1:],
+		"Package": strconv.Quote(o.Package),
+		"Name":    strconv.Quote(o.Name),
+	}); err != nil {
+		return nil, err
+	}
+	main := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(main, prog.Bytes(), 0644); err != nil {
+		return nil, err
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command("go", "run", "./"+filepath.ToSlash(dir))
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf(ErrIntrospect, v, err)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,193 @@
+package cst
+
+import (
+	"strings"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+// Builder constructs Parsers that produce concrete syntax tree Nodes
+type Builder struct {
+	trivia parse.Parser
+}
+
+// Node kinds produced by the Builder itself
+const (
+	// GapKind identifies tokens that cover text matched by a Rule's Parser
+	// without having produced a Node of its own
+	GapKind = ""
+
+	// EOFKind identifies the token produced by the Builder's EOF Parser
+	EOFKind = "EOF"
+)
+
+// NewBuilder returns a Builder that attaches any text matched by the
+// provided trivia Parser to the tokens it surrounds. Each match of the
+// trivia Parser becomes a separate Trivia. A nil trivia Parser disables
+// trivia handling
+func NewBuilder(trivia parse.Parser) *Builder {
+	return &Builder{
+		trivia: trivia,
+	}
+}
+
+// Token returns a new Parser that matches the provided Parser, producing a
+// token Node of the specified kind. Any trivia before the token becomes its
+// leading trivia, while trivia following it, up to and including the end of
+// the line, becomes its trailing trivia
+func (b *Builder) Token(kind string, p parse.Parser) parse.Parser {
+	return func(i parse.Input) (*parse.Success, *parse.Failure) {
+		leading, start := b.leading(i)
+		s, f := p(start)
+		if f != nil {
+			return nil, f
+		}
+		trailing, end := b.trailing(s.Remaining)
+		return &parse.Success{
+			Result: &Node{
+				Kind:     kind,
+				Text:     start.Until(s.Remaining),
+				span:     start.SpanTo(s.Remaining),
+				fullSpan: i.SpanTo(end),
+				leading:  leading,
+				trailing: trailing,
+			},
+			Remaining:   end,
+			Diagnostics: s.Diagnostics,
+		}, nil
+	}
+}
+
+// Rule returns a new Parser that matches the provided Parser, producing an
+// interior Node of the specified kind. The Nodes found in the Parser's
+// results become the Rule's Children, and any text matched between them is
+// covered by tokens of GapKind, so that no Input is ever lost
+func (b *Builder) Rule(kind string, p parse.Parser) parse.Parser {
+	return func(i parse.Input) (*parse.Success, *parse.Failure) {
+		s, f := p(i)
+		if f != nil {
+			return nil, f
+		}
+		n := &Node{
+			Kind:     kind,
+			fullSpan: i.SpanTo(s.Remaining),
+			children: fillGaps(i, s.Remaining, childNodes(s.Result)),
+		}
+		n.span = innerSpan(n)
+		return &parse.Success{
+			Result:      n,
+			Remaining:   s.Remaining,
+			Diagnostics: s.Diagnostics,
+		}, nil
+	}
+}
+
+// EOF returns a new Parser that matches the end of the Input, producing a
+// token of EOFKind that holds any trivia that remains
+func (b *Builder) EOF() parse.Parser {
+	return b.Token(EOFKind, parse.EOF)
+}
+
+func (b *Builder) leading(i parse.Input) ([]*Trivia, parse.Input) {
+	var res []*Trivia
+	for {
+		t, next, ok := b.match(i)
+		if !ok {
+			return res, i
+		}
+		res = append(res, t)
+		i = next
+	}
+}
+
+func (b *Builder) trailing(i parse.Input) ([]*Trivia, parse.Input) {
+	var res []*Trivia
+	for {
+		t, next, ok := b.match(i)
+		if !ok {
+			return res, i
+		}
+		res = append(res, t)
+		i = next
+		if strings.Contains(t.Text, "\n") {
+			return res, i
+		}
+	}
+}
+
+func (b *Builder) match(i parse.Input) (*Trivia, parse.Input, bool) {
+	if b.trivia == nil {
+		return nil, i, false
+	}
+	s, f := b.trivia(i)
+	if f != nil || s.Remaining.Offset() == i.Offset() {
+		return nil, i, false
+	}
+	return &Trivia{
+		Span: i.SpanTo(s.Remaining),
+		Text: i.Until(s.Remaining),
+	}, s.Remaining, true
+}
+
+func childNodes(r any) []*Node {
+	switch r := r.(type) {
+	case *Node:
+		return []*Node{r}
+	case parse.Results:
+		var res []*Node
+		for _, e := range r {
+			if n, ok := e.(*Node); ok {
+				res = append(res, n)
+			}
+		}
+		return res
+	default:
+		return nil
+	}
+}
+
+func fillGaps(start, end parse.Input, nodes []*Node) []*Node {
+	res := []*Node{}
+	pos := start.Offset()
+	gap := func(to int) {
+		span := parse.Span{Start: pos, End: to}
+		res = append(res, &Node{
+			Kind:     GapKind,
+			Text:     start.String()[pos-start.Offset() : to-start.Offset()],
+			span:     span,
+			fullSpan: span,
+		})
+	}
+	for _, n := range nodes {
+		full := n.FullSpan()
+		if full.Start < pos || full.End > end.Offset() {
+			continue
+		}
+		if full.Start > pos {
+			gap(full.Start)
+		}
+		res = append(res, n)
+		pos = full.End
+	}
+	if pos < end.Offset() {
+		gap(end.Offset())
+	}
+	return res
+}
+
+func innerSpan(n *Node) parse.Span {
+	tokens := n.Tokens()
+	for len(tokens) > 0 && tokens[0].Span().Len() == 0 {
+		tokens = tokens[1:]
+	}
+	for len(tokens) > 0 && tokens[len(tokens)-1].Span().Len() == 0 {
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) == 0 {
+		return n.fullSpan
+	}
+	return parse.Span{
+		Start: tokens[0].Span().Start,
+		End:   tokens[len(tokens)-1].Span().End,
+	}
+}
@@ -0,0 +1,125 @@
+package cst_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/kombi/cst"
+	"github.com/kode4food/kombi/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+func assignment() parse.Parser {
+	b := cst.NewBuilder(parse.Any(
+		parse.RegExp(`[ \t]+`),
+		parse.RegExp(`\n+`),
+		parse.RegExp(`//[^\n]*`),
+	))
+	ident := b.Token("ident", parse.RegExp("[a-z]+"))
+	number := b.Token("number", parse.RegExp("[0-9]+"))
+	stmt := b.Rule("assign", ident.
+		Concat(b.Token("=", parse.String("="))).
+		Concat(number).
+		Concat(parse.String(";")),
+	)
+	return b.Rule("program", stmt.ZeroOrMore().Concat(b.EOF()))
+}
+
+func TestRoundTrip(t *testing.T) {
+	as := assert.New(t)
+
+	src := "// header\n  x = 1;  // one\n\ty=22 ;\n\n// trailer\n"
+	s, f := assignment().Parse(src)
+	as.Nil(f)
+
+	root := s.Result.(*cst.Node)
+	as.Equal(src, root.String())
+	as.Equal("program", root.Kind)
+	as.Equal(parse.Span{Start: 0, End: len(src)}, root.FullSpan())
+	as.Equal(3, len(root.Children()))
+
+	x := root.Children()[0]
+	as.Equal("assign", x.Kind)
+	as.Equal("x = 1;", src[x.Span().Start:x.FullSpan().End])
+	as.Equal(parse.Span{Start: 12, End: 18}, x.Span())
+
+	tokens := x.Tokens()
+	as.Equal(4, len(tokens))
+	as.Equal("ident", tokens[0].Kind)
+	as.Equal("x", tokens[0].Text)
+	as.Equal("// header", tokens[0].Leading()[0].Text)
+	as.Equal("\n", tokens[0].Leading()[1].Text)
+	as.Equal("  ", tokens[0].Leading()[2].Text)
+	as.Equal(" ", tokens[0].Trailing()[0].Text)
+	as.Equal(cst.GapKind, tokens[3].Kind)
+	as.Equal(";", tokens[3].Text)
+	as.True(tokens[3].IsToken())
+	as.False(x.IsToken())
+
+	y := root.Children()[1]
+	as.Equal("y=22 ;", src[y.Span().Start:y.Span().End])
+	as.Equal(";", y.Children()[3].String())
+	as.Equal("  ", y.Children()[0].Leading()[0].Text)
+	as.Equal("// one", y.Children()[0].Leading()[1].Text)
+
+	eof := root.Children()[2]
+	as.Equal(cst.EOFKind, eof.Kind)
+	as.Equal("\n\n// trailer\n", eof.String())
+}
+
+func TestTokenFailure(t *testing.T) {
+	as := assert.New(t)
+
+	s, f := assignment().Parse("  x = ;")
+	as.Nil(s)
+	as.EqualError(f.Error, fmt.Sprintf(parse.ErrWrappedExpectation,
+		parse.ErrExpectedEndOfFile, "x = ;",
+	))
+	as.ErrorIs(f.Error, parse.ErrExpectedEndOfFile)
+}
+
+func TestNoTrivia(t *testing.T) {
+	as := assert.New(t)
+
+	b := cst.NewBuilder(nil)
+	p := b.Rule("pair", b.Token("a", parse.String("a")).
+		Concat(parse.Peek(b.Token("b", parse.RegExp(" *b")))).
+		Concat(parse.RegExp(" *b")),
+	)
+	s, f := p.Parse("a  b")
+	as.Nil(f)
+
+	n := s.Result.(*cst.Node)
+	as.Equal("a  b", n.String())
+	as.Equal(2, len(n.Children()))
+	as.Equal("  b", n.Children()[1].Text)
+	as.Equal(parse.Span{Start: 0, End: 4}, n.Span())
+}
+
+func TestRelocate(t *testing.T) {
+	as := assert.New(t)
+
+	src := "x = 1; // one\ny = 2;"
+	s, f := assignment().Parse(src)
+	as.Nil(f)
+	root := s.Result.(*cst.Node)
+
+	moved := root.Relocate(10).(*cst.Node)
+	as.Equal(src, moved.String())
+	as.Equal(parse.Span{Start: 10, End: 10 + len(src)}, moved.FullSpan())
+	as.Equal(parse.Span{Start: 0, End: len(src)}, root.FullSpan())
+
+	x := moved.Children()[0]
+	as.Equal(parse.Span{Start: 10, End: 16}, x.Span())
+	as.Equal(parse.Span{Start: 15, End: 16}, x.Tokens()[3].Span())
+
+	y := moved.Children()[1].Tokens()[0]
+	as.Equal("// one", y.Leading()[1].Text)
+	as.Equal(parse.Span{Start: 17, End: 23}, y.Leading()[1].Span)
+	y = root.Children()[1].Tokens()[0]
+	as.Equal(parse.Span{Start: 7, End: 13}, y.Leading()[1].Span)
+
+	back := moved.Relocate(-10).(*cst.Node)
+	as.Equal(root.Children()[1].Span(), back.Children()[1].Span())
+	as.Same(root, root.Relocate(0))
+}
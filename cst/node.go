@@ -0,0 +1,137 @@
+// Package cst builds concrete syntax trees that preserve every byte of their
+// Input. Tokens carry the whitespace and comments (trivia) that surround
+// them, and Rules cover everything matched by their Parsers, so that
+// printing a tree reproduces the original text exactly
+package cst
+
+import (
+	"strings"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+type (
+	// Node is a concrete syntax tree node. Tokens are leaf Nodes that have
+	// Text and trivia, while Rules are interior Nodes that have Children.
+	// Span excludes the trivia surrounding the Node, while FullSpan
+	// includes it
+	Node struct {
+		Kind     string
+		Text     string
+		span     parse.Span
+		fullSpan parse.Span
+		leading  []*Trivia
+		trailing []*Trivia
+		children []*Node
+		shift    int
+	}
+
+	// Trivia is source text that is insignificant to the grammar, such as
+	// whitespace or comments, but must be retained for round-tripping
+	Trivia struct {
+		Span parse.Span
+		Text string
+	}
+)
+
+// Span returns the Span of the Node, excluding its trivia
+func (n *Node) Span() parse.Span {
+	return n.span.Shift(n.shift)
+}
+
+// FullSpan returns the Span of the Node, including its trivia
+func (n *Node) FullSpan() parse.Span {
+	return n.fullSpan.Shift(n.shift)
+}
+
+// Leading returns the trivia that precedes a token
+func (n *Node) Leading() []*Trivia {
+	return shiftTrivia(n.leading, n.shift)
+}
+
+// Trailing returns the trivia that follows a token, up to and including
+// the end of its line
+func (n *Node) Trailing() []*Trivia {
+	return shiftTrivia(n.trailing, n.shift)
+}
+
+// Children returns the Nodes that a Rule is composed of
+func (n *Node) Children() []*Node {
+	if n.shift == 0 || n.children == nil {
+		return n.children
+	}
+	res := make([]*Node, len(n.children))
+	for i, c := range n.children {
+		res[i] = c.shifted(n.shift)
+	}
+	return res
+}
+
+// IsToken returns whether the Node is a leaf token
+func (n *Node) IsToken() bool {
+	return n.children == nil
+}
+
+// Tokens returns the leaf tokens of the Node in source order
+func (n *Node) Tokens() []*Node {
+	if n.IsToken() {
+		return []*Node{n}
+	}
+	var res []*Node
+	for _, c := range n.Children() {
+		res = append(res, c.Tokens()...)
+	}
+	return res
+}
+
+// Relocate returns a copy of the Node with all of its Spans shifted by the
+// provided delta. It allows memoized Nodes to be reused after an Edit. The
+// copy shares the Node's descendants, which are only shifted as they're
+// accessed, so a Node of any size is Relocated in constant time
+func (n *Node) Relocate(delta int) any {
+	return n.shifted(delta)
+}
+
+// String reproduces the exact source text covered by the Node, including
+// all of its trivia
+func (n *Node) String() string {
+	var buf strings.Builder
+	n.write(&buf)
+	return buf.String()
+}
+
+func (n *Node) shifted(delta int) *Node {
+	if delta == 0 {
+		return n
+	}
+	res := *n
+	res.shift += delta
+	return &res
+}
+
+func (n *Node) write(buf *strings.Builder) {
+	for _, t := range n.leading {
+		buf.WriteString(t.Text)
+	}
+	buf.WriteString(n.Text)
+	for _, c := range n.children {
+		c.write(buf)
+	}
+	for _, t := range n.trailing {
+		buf.WriteString(t.Text)
+	}
+}
+
+func shiftTrivia(t []*Trivia, delta int) []*Trivia {
+	if delta == 0 || t == nil {
+		return t
+	}
+	res := make([]*Trivia, len(t))
+	for i, e := range t {
+		res[i] = &Trivia{
+			Span: e.Span.Shift(delta),
+			Text: e.Text,
+		}
+	}
+	return res
+}
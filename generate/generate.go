@@ -0,0 +1,379 @@
+// Package generate produces standalone Go source code from a Grammar, which
+// may be parsed from PEG text or built in Go from the grammar package's
+// expression types. The generated Parsers match literals and perform
+// repetition inline rather than through chains of combinator closures, but
+// produce the same results and errors as a Grammar compiled by the grammar
+// package
+package generate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kode4food/kombi/grammar"
+)
+
+type (
+	// Options configure the generated source
+	Options struct {
+		// Package is the name of the generated source's package
+		Package string
+
+		// Name prefixes the generated identifiers. The exported constructor
+		// will be New<Name>
+		Name string
+	}
+
+	generator struct {
+		Options
+		grammar  *grammar.Grammar
+		rules    map[string]string
+		actions  []string
+		patterns []string
+		imports  map[string]bool
+		methods  bytes.Buffer
+		exprs    int
+	}
+)
+
+// Error messages
+const (
+	ErrMissingPackage = "generated package name is required"
+	ErrInvalidName    = "invalid generated name: %s"
+)
+
+const anyCharPattern = "(?s:.)"
+
+var validName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// Generate returns formatted Go source code for the provided Grammar
+func Generate(g *grammar.Grammar, o Options) ([]byte, error) {
+	if o.Package == "" {
+		return nil, errors.New(ErrMissingPackage)
+	}
+	if !validName.MatchString(o.Name) {
+		return nil, fmt.Errorf(ErrInvalidName, o.Name)
+	}
+	gen := &generator{
+		Options: o,
+		grammar: g,
+		rules:   make(map[string]string, len(g.Rules)),
+		imports: map[string]bool{
+			"github.com/kode4food/kombi/grammar": true,
+			"github.com/kode4food/kombi/parse":   true,
+		},
+	}
+	for i, r := range g.Rules {
+		gen.rules[r.Name] = fmt.Sprintf("rule%d", i)
+	}
+	for _, r := range g.Rules {
+		if err := gen.rule(r); err != nil {
+			return nil, err
+		}
+	}
+	return gen.source()
+}
+
+func (g *generator) rule(r *grammar.Rule) error {
+	name, err := g.expr(r.Expr)
+	if err != nil {
+		return err
+	}
+	g.method(g.rules[r.Name], r.String(), "\treturn p.%s(i)\n", name)
+	return nil
+}
+
+func (g *generator) expr(e grammar.Expr) (string, error) {
+	switch e := e.(type) {
+	case grammar.Choice:
+		return g.choice(e)
+	case grammar.Sequence:
+		return g.sequence(e)
+	case *grammar.Action:
+		return g.action(e)
+	case *grammar.ZeroOrMore:
+		return g.wrap(e, e.Expr, zeroOrMore)
+	case *grammar.OneOrMore:
+		return g.wrap(e, e.Expr, oneOrMore)
+	case *grammar.Optional:
+		return g.wrap(e, e.Expr, optional)
+	case *grammar.And:
+		return g.wrap(e, e.Expr, and)
+	case *grammar.Not:
+		return g.wrap(e, e.Expr, not)
+	case *grammar.Ref:
+		return g.ref(e)
+	case *grammar.Literal:
+		return g.literal(e), nil
+	case *grammar.Class:
+		return g.class(e)
+	case *grammar.AnyChar:
+		return g.anyChar(e), nil
+	default:
+		return "", fmt.Errorf(grammar.ErrUnknownExpr, e)
+	}
+}
+
+func (g *generator) choice(e grammar.Choice) (string, error) {
+	names, err := g.children(e)
+	if err != nil {
+		return "", err
+	}
+	var body strings.Builder
+	last := len(names) - 1
+	for _, n := range names[:last] {
+		fmt.Fprintf(&body, "\tif s, f := p.%s(i); f == nil {\n", n)
+		body.WriteString("\t\treturn s, nil\n\t}\n")
+	}
+	fmt.Fprintf(&body, "\treturn p.%s(i)\n", names[last])
+	return g.exprMethod(e, body.String()), nil
+}
+
+func (g *generator) sequence(e grammar.Sequence) (string, error) {
+	names, err := g.children(e)
+	if err != nil {
+		return "", err
+	}
+	var body strings.Builder
+	body.WriteString("\tres := parse.Results{}\n")
+	fmt.Fprintf(&body, "\ts, f := p.%s(i)\n", names[0])
+	for i, n := range names {
+		if i > 0 {
+			fmt.Fprintf(&body, "\ts, f = p.%s(s.Remaining)\n", n)
+		}
+		body.WriteString("\tif f != nil {\n\t\treturn nil, f\n\t}\n")
+		body.WriteString("\tres = p.appendResults(res, s.Result)\n")
+	}
+	body.WriteString(
+		"\treturn &parse.Success{Result: res, Remaining: s.Remaining}, nil\n",
+	)
+	return g.exprMethod(e, body.String()), nil
+}
+
+func (g *generator) action(e *grammar.Action) (string, error) {
+	child, err := g.expr(e.Expr)
+	if err != nil {
+		return "", err
+	}
+	idx := indexOf(&g.actions, e.Name)
+	return g.exprMethod(e, fmt.Sprintf(
+		"\ts, f := p.%s(i)\n"+
+			"\tif f != nil {\n\t\treturn nil, f\n\t}\n"+
+			"\treturn &parse.Success{\n"+
+			"\t\tResult:    p.actions[%d](s.Result),\n"+
+			"\t\tRemaining: s.Remaining,\n"+
+			"\t}, nil\n",
+		child, idx,
+	)), nil
+}
+
+func (g *generator) wrap(
+	e grammar.Expr, child grammar.Expr, tmpl string,
+) (string, error) {
+	name, err := g.expr(child)
+	if err != nil {
+		return "", err
+	}
+	return g.exprMethod(e, strings.ReplaceAll(tmpl, "CHILD", name)), nil
+}
+
+func (g *generator) ref(e *grammar.Ref) (string, error) {
+	if name, ok := g.rules[e.Name]; ok {
+		return name, nil
+	}
+	return "", fmt.Errorf(grammar.ErrUndefinedRule, e.Name)
+}
+
+func (g *generator) literal(e *grammar.Literal) string {
+	v := strconv.Quote(e.Value)
+	g.imports["strings"] = true
+	if e.IgnoreCase {
+		upper := strings.ToUpper(e.Value)
+		return g.exprMethod(e, fmt.Sprintf(
+			"\tif str := i.String(); len(str) >= %d {\n"+
+				"\t\tif m := str[:%d]; strings.ToUpper(m) == %s {\n"+
+				"\t\t\treturn &parse.Success{Result: m, Remaining: i.Advance(%d)}, nil\n"+
+				"\t\t}\n"+
+				"\t}\n"+
+				"\treturn nil, &parse.Failure{\n"+
+				"\t\tError: i.Expected(parse.ErrExpectedString, %s),\n"+
+				"\t\tInput: i,\n"+
+				"\t}\n",
+			len(upper), len(upper), strconv.Quote(upper), len(upper), v,
+		))
+	}
+	return g.exprMethod(e, fmt.Sprintf(
+		"\tif strings.HasPrefix(i.String(), %s) {\n"+
+			"\t\treturn &parse.Success{Result: %s, Remaining: i.Advance(%d)}, nil\n"+
+			"\t}\n"+
+			"\treturn nil, &parse.Failure{\n"+
+			"\t\tError: i.Expected(parse.ErrExpectedString, %s),\n"+
+			"\t\tInput: i,\n"+
+			"\t}\n",
+		v, v, len(e.Value), v,
+	))
+}
+
+func (g *generator) class(e *grammar.Class) (string, error) {
+	pattern := e.Pattern
+	if e.IgnoreCase {
+		pattern = "(?i:" + pattern + ")"
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return "", err
+	}
+	g.imports["regexp"] = true
+	v := fmt.Sprintf("%sPattern%d", g.prefix(), indexOf(&g.patterns, pattern))
+	return g.exprMethod(e, fmt.Sprintf(
+		"\tif m := %s.FindStringIndex(i.String()); m != nil {\n"+
+			"\t\treturn &parse.Success{\n"+
+			"\t\t\tResult:    i.String()[:m[1]],\n"+
+			"\t\t\tRemaining: i.Advance(m[1]),\n"+
+			"\t\t}, nil\n"+
+			"\t}\n"+
+			"\treturn nil, &parse.Failure{\n"+
+			"\t\tError: i.Expected(parse.ErrExpectedPattern, %s),\n"+
+			"\t\tInput: i,\n"+
+			"\t}\n",
+		v, strconv.Quote(pattern),
+	)), nil
+}
+
+func (g *generator) anyChar(e *grammar.AnyChar) string {
+	g.imports["unicode/utf8"] = true
+	return g.exprMethod(e, fmt.Sprintf(
+		"\tif str := i.String(); len(str) > 0 {\n"+
+			"\t\t_, n := utf8.DecodeRuneInString(str)\n"+
+			"\t\treturn &parse.Success{Result: str[:n], Remaining: i.Advance(n)}, nil\n"+
+			"\t}\n"+
+			"\treturn nil, &parse.Failure{\n"+
+			"\t\tError: i.Expected(parse.ErrExpectedPattern, %s),\n"+
+			"\t\tInput: i,\n"+
+			"\t}\n",
+		strconv.Quote(anyCharPattern),
+	))
+}
+
+func (g *generator) children(e []grammar.Expr) ([]string, error) {
+	res := make([]string, len(e))
+	for i, c := range e {
+		n, err := g.expr(c)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = n
+	}
+	return res, nil
+}
+
+func (g *generator) exprMethod(e grammar.Expr, body string) string {
+	name := fmt.Sprintf("expr%d", g.exprs)
+	g.exprs++
+	g.method(name, e.String(), "%s", body)
+	return name
+}
+
+func (g *generator) method(name, doc, body string, args ...any) {
+	fmt.Fprintf(&g.methods, "\n// %s matches: %s\n", name, doc)
+	fmt.Fprintf(&g.methods,
+		"func (p *%s) %s(i parse.Input) (*parse.Success, *parse.Failure) {\n",
+		g.typeName(), name,
+	)
+	fmt.Fprintf(&g.methods, body, args...)
+	g.methods.WriteString("}\n")
+}
+
+func (g *generator) prefix() string {
+	return strings.ToLower(g.Name[:1]) + g.Name[1:]
+}
+
+func (g *generator) typeName() string {
+	return g.prefix() + "Parser"
+}
+
+func (g *generator) source() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by kombigen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", g.Package)
+
+	var std, other []string
+	for i := range g.imports {
+		if strings.Contains(strings.Split(i, "/")[0], ".") {
+			other = append(other, i)
+			continue
+		}
+		std = append(std, i)
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	buf.WriteString("import (\n")
+	for _, i := range std {
+		fmt.Fprintf(&buf, "\t%s\n", strconv.Quote(i))
+	}
+	buf.WriteString("\n")
+	for _, i := range other {
+		fmt.Fprintf(&buf, "\t%s\n", strconv.Quote(i))
+	}
+	buf.WriteString(")\n\n")
+
+	fmt.Fprintf(&buf, "type %s struct {\n", g.typeName())
+	buf.WriteString("\tactions []parse.Mapper\n}\n\n")
+
+	if len(g.patterns) > 0 {
+		buf.WriteString("var (\n")
+		for i, p := range g.patterns {
+			fmt.Fprintf(&buf, "\t%sPattern%d = regexp.MustCompile(%s)\n",
+				g.prefix(), i, strconv.Quote("^("+p+")"),
+			)
+		}
+		buf.WriteString(")\n\n")
+	}
+
+	fmt.Fprintf(&buf,
+		"// New%s returns the generated Parsers, keyed by rule name, binding\n"+
+			"// their actions to the functions in the provided Registry\n"+
+			"func New%s(reg *grammar.Registry) (grammar.Parsers, error) {\n",
+		g.Name, g.Name,
+	)
+	fmt.Fprintf(&buf, "\tp := &%s{\n", g.typeName())
+	fmt.Fprintf(&buf, "\t\tactions: make([]parse.Mapper, %d),\n\t}\n",
+		len(g.actions),
+	)
+	if len(g.actions) > 0 {
+		buf.WriteString("\tfor i, name := range []string{\n")
+		for _, a := range g.actions {
+			fmt.Fprintf(&buf, "\t\t%s,\n", strconv.Quote(a))
+		}
+		buf.WriteString("\t} {\n")
+		buf.WriteString("\t\tfn, err := reg.Action(name)\n")
+		buf.WriteString("\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n")
+		buf.WriteString("\t\tp.actions[i] = fn\n\t}\n")
+	}
+	buf.WriteString("\treturn grammar.Parsers{\n")
+	for _, r := range g.grammar.Rules {
+		fmt.Fprintf(&buf, "\t\t%s: p.%s,\n",
+			strconv.Quote(r.Name), g.rules[r.Name],
+		)
+	}
+	buf.WriteString("\t}, nil\n}\n")
+
+	buf.Write(g.methods.Bytes())
+	fmt.Fprintf(&buf, appendResults, g.typeName())
+	return format.Source(buf.Bytes())
+}
+
+func indexOf(s *[]string, v string) int {
+	for i, e := range *s {
+		if e == v {
+			return i
+		}
+	}
+	*s = append(*s, v)
+	return len(*s) - 1
+}
@@ -0,0 +1,65 @@
+package generate_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/kode4food/kombi/generate"
+	"github.com/kode4food/kombi/generate/internal/csv"
+	"github.com/kode4food/kombi/grammar"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateUpToDate(t *testing.T) {
+	as := assert.New(t)
+
+	src, err := os.ReadFile("internal/calc/calc.peg")
+	as.Nil(err)
+	g, err := grammar.Parse(string(src))
+	as.Nil(err)
+
+	res, err := generate.Generate(g, generate.Options{
+		Package: "calc",
+		Name:    "Calc",
+	})
+	as.Nil(err)
+
+	gen, err := os.ReadFile("internal/calc/calc_gen.go")
+	as.Nil(err)
+	as.Equal(string(gen), string(res))
+}
+
+func TestGenerateIntrospected(t *testing.T) {
+	as := assert.New(t)
+
+	res, err := generate.Generate(csv.Grammar, generate.Options{
+		Package: "csv",
+		Name:    "Records",
+	})
+	as.Nil(err)
+
+	gen, err := os.ReadFile("internal/csv/csv_gen.go")
+	as.Nil(err)
+	as.Equal(string(gen), string(res))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	as := assert.New(t)
+
+	g, _ := grammar.Parse(`A <- "a"`)
+	_, err := generate.Generate(g, generate.Options{Name: "A"})
+	as.EqualError(err, generate.ErrMissingPackage)
+
+	_, err = generate.Generate(g, generate.Options{Package: "a", Name: "1a"})
+	as.EqualError(err, fmt.Sprintf(generate.ErrInvalidName, "1a"))
+
+	opts := generate.Options{Package: "a", Name: "A"}
+	g, _ = grammar.Parse(`A <- B`)
+	_, err = generate.Generate(g, opts)
+	as.EqualError(err, fmt.Sprintf(grammar.ErrUndefinedRule, "B"))
+
+	g, _ = grammar.Parse(`A <- [z-a]`)
+	_, err = generate.Generate(g, opts)
+	as.NotNil(err)
+}
@@ -0,0 +1,4 @@
+// Package calc is an example of a Parser generated by kombigen
+package calc
+
+//go:generate go run github.com/kode4food/kombi/cmd/kombigen -pkg calc -name Calc -o calc_gen.go calc.peg
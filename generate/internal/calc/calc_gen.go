@@ -0,0 +1,865 @@
+// Code generated by kombigen. DO NOT EDIT.
+
+package calc
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kode4food/kombi/grammar"
+	"github.com/kode4food/kombi/parse"
+)
+
+type calcParser struct {
+	actions []parse.Mapper
+}
+
+var (
+	calcPattern0 = regexp.MustCompile("^([a-z])")
+	calcPattern1 = regexp.MustCompile("^([0-9])")
+	calcPattern2 = regexp.MustCompile("^([+\\-])")
+	calcPattern3 = regexp.MustCompile("^([*/])")
+	calcPattern4 = regexp.MustCompile("^([ \\t\\n])")
+)
+
+// NewCalc returns the generated Parsers, keyed by rule name, binding
+// their actions to the functions in the provided Registry
+func NewCalc(reg *grammar.Registry) (grammar.Parsers, error) {
+	p := &calcParser{
+		actions: make([]parse.Mapper, 6),
+	}
+	for i, name := range []string{
+		"first",
+		"fold",
+		"group",
+		"call",
+		"join",
+		"int",
+	} {
+		fn, err := reg.Action(name)
+		if err != nil {
+			return nil, err
+		}
+		p.actions[i] = fn
+	}
+	return grammar.Parsers{
+		"Expr":    p.rule0,
+		"Sum":     p.rule1,
+		"Product": p.rule2,
+		"Value":   p.rule3,
+		"Call":    p.rule4,
+		"Ident":   p.rule5,
+		"Keyword": p.rule6,
+		"Number":  p.rule7,
+		"AddOp":   p.rule8,
+		"MulOp":   p.rule9,
+		"_":       p.rule10,
+	}, nil
+}
+
+// expr0 matches: .
+func (p *calcParser) expr0(i parse.Input) (*parse.Success, *parse.Failure) {
+	if str := i.String(); len(str) > 0 {
+		_, n := utf8.DecodeRuneInString(str)
+		return &parse.Success{Result: str[:n], Remaining: i.Advance(n)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedPattern, "(?s:.)"),
+		Input: i,
+	}
+}
+
+// expr1 matches: !.
+func (p *calcParser) expr1(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr0(i)
+	if f != nil {
+		return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+	}
+	return nil, &parse.Failure{
+		Error: &parse.Error{
+			Err:  parse.ErrUnexpectedInput,
+			Args: []any{i.Until(s.Remaining)},
+		},
+		Input: i,
+	}
+}
+
+// expr2 matches: _ Sum !.
+func (p *calcParser) expr2(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.rule10(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule1(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr1(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr3 matches: _ Sum !. {first}
+func (p *calcParser) expr3(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr2(i)
+	if f != nil {
+		return nil, f
+	}
+	return &parse.Success{
+		Result:    p.actions[0](s.Result),
+		Remaining: s.Remaining,
+	}, nil
+}
+
+// rule0 matches: Expr <- _ Sum !. {first}
+func (p *calcParser) rule0(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr3(i)
+}
+
+// expr4 matches: AddOp Product
+func (p *calcParser) expr4(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.rule8(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule2(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr5 matches: (AddOp Product)*
+func (p *calcParser) expr5(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr4(i)
+	if f != nil {
+		return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+	}
+	res := parse.Results{}
+	for f == nil {
+		res = p.appendResults(res, s.Result)
+		i = s.Remaining
+		s, f = p.expr4(i)
+	}
+	return &parse.Success{Result: res, Remaining: i}, nil
+}
+
+// expr6 matches: Product (AddOp Product)*
+func (p *calcParser) expr6(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.rule2(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr5(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr7 matches: Product (AddOp Product)* {fold}
+func (p *calcParser) expr7(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr6(i)
+	if f != nil {
+		return nil, f
+	}
+	return &parse.Success{
+		Result:    p.actions[1](s.Result),
+		Remaining: s.Remaining,
+	}, nil
+}
+
+// rule1 matches: Sum <- Product (AddOp Product)* {fold}
+func (p *calcParser) rule1(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr7(i)
+}
+
+// expr8 matches: MulOp Value
+func (p *calcParser) expr8(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.rule9(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule3(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr9 matches: (MulOp Value)*
+func (p *calcParser) expr9(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr8(i)
+	if f != nil {
+		return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+	}
+	res := parse.Results{}
+	for f == nil {
+		res = p.appendResults(res, s.Result)
+		i = s.Remaining
+		s, f = p.expr8(i)
+	}
+	return &parse.Success{Result: res, Remaining: i}, nil
+}
+
+// expr10 matches: Value (MulOp Value)*
+func (p *calcParser) expr10(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.rule3(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr9(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr11 matches: Value (MulOp Value)* {fold}
+func (p *calcParser) expr11(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr10(i)
+	if f != nil {
+		return nil, f
+	}
+	return &parse.Success{
+		Result:    p.actions[1](s.Result),
+		Remaining: s.Remaining,
+	}, nil
+}
+
+// rule2 matches: Product <- Value (MulOp Value)* {fold}
+func (p *calcParser) rule2(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr11(i)
+}
+
+// expr12 matches: "("
+func (p *calcParser) expr12(i parse.Input) (*parse.Success, *parse.Failure) {
+	if strings.HasPrefix(i.String(), "(") {
+		return &parse.Success{Result: "(", Remaining: i.Advance(1)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedString, "("),
+		Input: i,
+	}
+}
+
+// expr13 matches: ")"
+func (p *calcParser) expr13(i parse.Input) (*parse.Success, *parse.Failure) {
+	if strings.HasPrefix(i.String(), ")") {
+		return &parse.Success{Result: ")", Remaining: i.Advance(1)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedString, ")"),
+		Input: i,
+	}
+}
+
+// expr14 matches: "(" _ Sum ")" _
+func (p *calcParser) expr14(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.expr12(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule10(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule1(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr13(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule10(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr15 matches: "(" _ Sum ")" _ {group}
+func (p *calcParser) expr15(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr14(i)
+	if f != nil {
+		return nil, f
+	}
+	return &parse.Success{
+		Result:    p.actions[2](s.Result),
+		Remaining: s.Remaining,
+	}, nil
+}
+
+// expr16 matches: Number / Call / "(" _ Sum ")" _ {group}
+func (p *calcParser) expr16(i parse.Input) (*parse.Success, *parse.Failure) {
+	if s, f := p.rule7(i); f == nil {
+		return s, nil
+	}
+	if s, f := p.rule4(i); f == nil {
+		return s, nil
+	}
+	return p.expr15(i)
+}
+
+// rule3 matches: Value <- Number / Call / "(" _ Sum ")" _ {group}
+func (p *calcParser) rule3(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr16(i)
+}
+
+// expr17 matches: "("
+func (p *calcParser) expr17(i parse.Input) (*parse.Success, *parse.Failure) {
+	if strings.HasPrefix(i.String(), "(") {
+		return &parse.Success{Result: "(", Remaining: i.Advance(1)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedString, "("),
+		Input: i,
+	}
+}
+
+// expr18 matches: ")"
+func (p *calcParser) expr18(i parse.Input) (*parse.Success, *parse.Failure) {
+	if strings.HasPrefix(i.String(), ")") {
+		return &parse.Success{Result: ")", Remaining: i.Advance(1)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedString, ")"),
+		Input: i,
+	}
+}
+
+// expr19 matches: Ident "(" _ Sum ")" _
+func (p *calcParser) expr19(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.rule5(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr17(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule10(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule1(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr18(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule10(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr20 matches: Ident "(" _ Sum ")" _ {call}
+func (p *calcParser) expr20(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr19(i)
+	if f != nil {
+		return nil, f
+	}
+	return &parse.Success{
+		Result:    p.actions[3](s.Result),
+		Remaining: s.Remaining,
+	}, nil
+}
+
+// rule4 matches: Call <- Ident "(" _ Sum ")" _ {call}
+func (p *calcParser) rule4(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr20(i)
+}
+
+// expr21 matches: !Keyword
+func (p *calcParser) expr21(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.rule6(i)
+	if f != nil {
+		return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+	}
+	return nil, &parse.Failure{
+		Error: &parse.Error{
+			Err:  parse.ErrUnexpectedInput,
+			Args: []any{i.Until(s.Remaining)},
+		},
+		Input: i,
+	}
+}
+
+// expr22 matches: [a-z]
+func (p *calcParser) expr22(i parse.Input) (*parse.Success, *parse.Failure) {
+	if m := calcPattern0.FindStringIndex(i.String()); m != nil {
+		return &parse.Success{
+			Result:    i.String()[:m[1]],
+			Remaining: i.Advance(m[1]),
+		}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedPattern, "[a-z]"),
+		Input: i,
+	}
+}
+
+// expr23 matches: [a-z]+
+func (p *calcParser) expr23(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr22(i)
+	if f != nil {
+		return nil, f
+	}
+	res := parse.Results{}
+	for f == nil {
+		res = p.appendResults(res, s.Result)
+		i = s.Remaining
+		s, f = p.expr22(i)
+	}
+	return &parse.Success{Result: res, Remaining: i}, nil
+}
+
+// expr24 matches: !Keyword [a-z]+ _
+func (p *calcParser) expr24(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.expr21(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr23(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule10(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr25 matches: !Keyword [a-z]+ _ {join}
+func (p *calcParser) expr25(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr24(i)
+	if f != nil {
+		return nil, f
+	}
+	return &parse.Success{
+		Result:    p.actions[4](s.Result),
+		Remaining: s.Remaining,
+	}, nil
+}
+
+// rule5 matches: Ident <- !Keyword [a-z]+ _ {join}
+func (p *calcParser) rule5(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr25(i)
+}
+
+// expr26 matches: "let"i
+func (p *calcParser) expr26(i parse.Input) (*parse.Success, *parse.Failure) {
+	if str := i.String(); len(str) >= 3 {
+		if m := str[:3]; strings.ToUpper(m) == "LET" {
+			return &parse.Success{Result: m, Remaining: i.Advance(3)}, nil
+		}
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedString, "let"),
+		Input: i,
+	}
+}
+
+// expr27 matches: "if"
+func (p *calcParser) expr27(i parse.Input) (*parse.Success, *parse.Failure) {
+	if strings.HasPrefix(i.String(), "if") {
+		return &parse.Success{Result: "if", Remaining: i.Advance(2)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedString, "if"),
+		Input: i,
+	}
+}
+
+// expr28 matches: "let"i / "if"
+func (p *calcParser) expr28(i parse.Input) (*parse.Success, *parse.Failure) {
+	if s, f := p.expr26(i); f == nil {
+		return s, nil
+	}
+	return p.expr27(i)
+}
+
+// expr29 matches: [a-z]
+func (p *calcParser) expr29(i parse.Input) (*parse.Success, *parse.Failure) {
+	if m := calcPattern0.FindStringIndex(i.String()); m != nil {
+		return &parse.Success{
+			Result:    i.String()[:m[1]],
+			Remaining: i.Advance(m[1]),
+		}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedPattern, "[a-z]"),
+		Input: i,
+	}
+}
+
+// expr30 matches: ![a-z]
+func (p *calcParser) expr30(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr29(i)
+	if f != nil {
+		return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+	}
+	return nil, &parse.Failure{
+		Error: &parse.Error{
+			Err:  parse.ErrUnexpectedInput,
+			Args: []any{i.Until(s.Remaining)},
+		},
+		Input: i,
+	}
+}
+
+// expr31 matches: ("let"i / "if") ![a-z]
+func (p *calcParser) expr31(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.expr28(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr30(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// rule6 matches: Keyword <- ("let"i / "if") ![a-z]
+func (p *calcParser) rule6(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr31(i)
+}
+
+// expr32 matches: [0-9]
+func (p *calcParser) expr32(i parse.Input) (*parse.Success, *parse.Failure) {
+	if m := calcPattern1.FindStringIndex(i.String()); m != nil {
+		return &parse.Success{
+			Result:    i.String()[:m[1]],
+			Remaining: i.Advance(m[1]),
+		}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedPattern, "[0-9]"),
+		Input: i,
+	}
+}
+
+// expr33 matches: &[0-9]
+func (p *calcParser) expr33(i parse.Input) (*parse.Success, *parse.Failure) {
+	if _, f := p.expr32(i); f != nil {
+		return nil, f
+	}
+	return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+}
+
+// expr34 matches: [0-9]
+func (p *calcParser) expr34(i parse.Input) (*parse.Success, *parse.Failure) {
+	if m := calcPattern1.FindStringIndex(i.String()); m != nil {
+		return &parse.Success{
+			Result:    i.String()[:m[1]],
+			Remaining: i.Advance(m[1]),
+		}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedPattern, "[0-9]"),
+		Input: i,
+	}
+}
+
+// expr35 matches: [0-9]+
+func (p *calcParser) expr35(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr34(i)
+	if f != nil {
+		return nil, f
+	}
+	res := parse.Results{}
+	for f == nil {
+		res = p.appendResults(res, s.Result)
+		i = s.Remaining
+		s, f = p.expr34(i)
+	}
+	return &parse.Success{Result: res, Remaining: i}, nil
+}
+
+// expr36 matches: "."
+func (p *calcParser) expr36(i parse.Input) (*parse.Success, *parse.Failure) {
+	if strings.HasPrefix(i.String(), ".") {
+		return &parse.Success{Result: ".", Remaining: i.Advance(1)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedString, "."),
+		Input: i,
+	}
+}
+
+// expr37 matches: [0-9]
+func (p *calcParser) expr37(i parse.Input) (*parse.Success, *parse.Failure) {
+	if m := calcPattern1.FindStringIndex(i.String()); m != nil {
+		return &parse.Success{
+			Result:    i.String()[:m[1]],
+			Remaining: i.Advance(m[1]),
+		}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedPattern, "[0-9]"),
+		Input: i,
+	}
+}
+
+// expr38 matches: [0-9]+
+func (p *calcParser) expr38(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr37(i)
+	if f != nil {
+		return nil, f
+	}
+	res := parse.Results{}
+	for f == nil {
+		res = p.appendResults(res, s.Result)
+		i = s.Remaining
+		s, f = p.expr37(i)
+	}
+	return &parse.Success{Result: res, Remaining: i}, nil
+}
+
+// expr39 matches: "." [0-9]+
+func (p *calcParser) expr39(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.expr36(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr38(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr40 matches: ("." [0-9]+)?
+func (p *calcParser) expr40(i parse.Input) (*parse.Success, *parse.Failure) {
+	if s, f := p.expr39(i); f == nil {
+		return s, nil
+	}
+	return &parse.Success{Result: nil, Remaining: i}, nil
+}
+
+// expr41 matches: &[0-9] [0-9]+ ("." [0-9]+)? _
+func (p *calcParser) expr41(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.expr33(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr35(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr40(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule10(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr42 matches: &[0-9] [0-9]+ ("." [0-9]+)? _ {int}
+func (p *calcParser) expr42(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr41(i)
+	if f != nil {
+		return nil, f
+	}
+	return &parse.Success{
+		Result:    p.actions[5](s.Result),
+		Remaining: s.Remaining,
+	}, nil
+}
+
+// rule7 matches: Number <- &[0-9] [0-9]+ ("." [0-9]+)? _ {int}
+func (p *calcParser) rule7(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr42(i)
+}
+
+// expr43 matches: [+\-]
+func (p *calcParser) expr43(i parse.Input) (*parse.Success, *parse.Failure) {
+	if m := calcPattern2.FindStringIndex(i.String()); m != nil {
+		return &parse.Success{
+			Result:    i.String()[:m[1]],
+			Remaining: i.Advance(m[1]),
+		}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedPattern, "[+\\-]"),
+		Input: i,
+	}
+}
+
+// expr44 matches: [+\-] _
+func (p *calcParser) expr44(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.expr43(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule10(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr45 matches: [+\-] _ {first}
+func (p *calcParser) expr45(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr44(i)
+	if f != nil {
+		return nil, f
+	}
+	return &parse.Success{
+		Result:    p.actions[0](s.Result),
+		Remaining: s.Remaining,
+	}, nil
+}
+
+// rule8 matches: AddOp <- [+\-] _ {first}
+func (p *calcParser) rule8(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr45(i)
+}
+
+// expr46 matches: [*/]
+func (p *calcParser) expr46(i parse.Input) (*parse.Success, *parse.Failure) {
+	if m := calcPattern3.FindStringIndex(i.String()); m != nil {
+		return &parse.Success{
+			Result:    i.String()[:m[1]],
+			Remaining: i.Advance(m[1]),
+		}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedPattern, "[*/]"),
+		Input: i,
+	}
+}
+
+// expr47 matches: [*/] _
+func (p *calcParser) expr47(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.expr46(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule10(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr48 matches: [*/] _ {first}
+func (p *calcParser) expr48(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr47(i)
+	if f != nil {
+		return nil, f
+	}
+	return &parse.Success{
+		Result:    p.actions[0](s.Result),
+		Remaining: s.Remaining,
+	}, nil
+}
+
+// rule9 matches: MulOp <- [*/] _ {first}
+func (p *calcParser) rule9(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr48(i)
+}
+
+// expr49 matches: [ \t\n]
+func (p *calcParser) expr49(i parse.Input) (*parse.Success, *parse.Failure) {
+	if m := calcPattern4.FindStringIndex(i.String()); m != nil {
+		return &parse.Success{
+			Result:    i.String()[:m[1]],
+			Remaining: i.Advance(m[1]),
+		}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedPattern, "[ \\t\\n]"),
+		Input: i,
+	}
+}
+
+// expr50 matches: [ \t\n]*
+func (p *calcParser) expr50(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr49(i)
+	if f != nil {
+		return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+	}
+	res := parse.Results{}
+	for f == nil {
+		res = p.appendResults(res, s.Result)
+		i = s.Remaining
+		s, f = p.expr49(i)
+	}
+	return &parse.Success{Result: res, Remaining: i}, nil
+}
+
+// rule10 matches: _ <- [ \t\n]*
+func (p *calcParser) rule10(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr50(i)
+}
+
+func (*calcParser) appendResults(res parse.Results, r any) parse.Results {
+	if c, ok := r.(parse.Results); ok {
+		return append(res, c...)
+	}
+	return append(res, r)
+}
@@ -0,0 +1,115 @@
+package calc_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/kode4food/kombi/generate/internal/calc"
+	"github.com/kode4food/kombi/grammar"
+	"github.com/kode4food/kombi/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+var inputs = []string{
+	"1 + 2 * (3 - 4)",
+	"  foo(1.5) / bar(2 * x(3))",
+	"let(1)",
+	"IF(1) + 2",
+	"iffy(1)",
+	"1 +",
+	"1 + 2 $",
+	"(1 + 2",
+	"1..2",
+	"",
+	"this input is far too long to be shown in full",
+}
+
+func registry() *grammar.Registry {
+	reg := grammar.NewRegistry()
+	for _, name := range []string{"first", "fold", "group", "call", "join"} {
+		name := name
+		reg.Combiner(name, func(r ...any) any {
+			return fmt.Sprintf("%s%q", name, r)
+		})
+	}
+	return reg.Mapper("int", func(r any) any {
+		return fmt.Sprintf("int(%q)", r)
+	})
+}
+
+func TestGenerated(t *testing.T) {
+	as := assert.New(t)
+
+	src, err := os.ReadFile("calc.peg")
+	as.Nil(err)
+	g, err := grammar.Parse(string(src))
+	as.Nil(err)
+	compiled, err := g.Compile(registry())
+	as.Nil(err)
+	generated, err := calc.NewCalc(registry())
+	as.Nil(err)
+	as.Equal(len(compiled), len(generated))
+
+	s, f := generated["Expr"].Parse(inputs[0])
+	as.Nil(f)
+	as.Equal("", s.Remaining.String())
+
+	for name, c := range compiled {
+		gen := generated[name]
+		for _, in := range inputs {
+			cs, cf := c.Parse(in)
+			gs, gf := gen.Parse(in)
+			if cf == nil {
+				as.Nil(gf)
+				as.Equal(cs.Result, gs.Result, "rule %s, input %q", name, in)
+				as.Equal(cs.Remaining.Offset(), gs.Remaining.Offset())
+				continue
+			}
+			as.Nil(gs)
+			as.Equal(cf.Input.Offset(), gf.Input.Offset(),
+				"rule %s, input %q", name, in,
+			)
+			as.EqualError(gf.Error, cf.Error.Error())
+		}
+	}
+}
+
+func TestMissingAction(t *testing.T) {
+	as := assert.New(t)
+
+	_, err := calc.NewCalc(grammar.NewRegistry())
+	as.EqualError(err, fmt.Sprintf(grammar.ErrUnknownAction, "first"))
+}
+
+func BenchmarkCompiled(b *testing.B) {
+	src, _ := os.ReadFile("calc.peg")
+	g, _ := grammar.Parse(string(src))
+	p, _ := g.Compile(benchmarkRegistry())
+	benchmarkParser(b, p["Expr"])
+}
+
+func BenchmarkGenerated(b *testing.B) {
+	p, _ := calc.NewCalc(benchmarkRegistry())
+	benchmarkParser(b, p["Expr"])
+}
+
+func benchmarkRegistry() *grammar.Registry {
+	reg := grammar.NewRegistry()
+	for _, name := range []string{"first", "fold", "group", "call", "join"} {
+		reg.Combiner(name, func(r ...any) any {
+			return r[0]
+		})
+	}
+	return reg.Mapper("int", func(r any) any {
+		return r
+	})
+}
+
+func benchmarkParser(b *testing.B, p parse.Parser) {
+	for n := 0; n < b.N; n++ {
+		if _, f := p.Parse(inputs[1]); f != nil {
+			b.Fatal(f.Error)
+		}
+	}
+}
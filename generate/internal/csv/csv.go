@@ -0,0 +1,51 @@
+// Package csv is an example of a Parser generated by kombigen from a
+// Grammar that is built in Go rather than parsed from PEG text
+package csv
+
+import "github.com/kode4food/kombi/grammar"
+
+//go:generate go run github.com/kode4food/kombi/cmd/kombigen -pkg csv -name Records -o csv_gen.go -var github.com/kode4food/kombi/generate/internal/csv.Grammar
+
+// Grammar matches comma-separated records, one per line, whose fields may
+// be quoted. It is equivalent to the following PEG text:
+//
+//	File   <- Record ("\n" Record)* "\n"? !.
+//	Record <- Field ("," Field)*
+//	Field  <- Quoted / Bare
+//	Quoted <- "\"" ([^"] / "\"\"")* "\""
+//	Bare   <- [^,\n"]*
+var Grammar = &grammar.Grammar{
+	Rules: []*grammar.Rule{
+		{Name: "File", Expr: grammar.Sequence{
+			&grammar.Ref{Name: "Record"},
+			&grammar.ZeroOrMore{Expr: grammar.Sequence{
+				&grammar.Literal{Value: "\n"},
+				&grammar.Ref{Name: "Record"},
+			}},
+			&grammar.Optional{Expr: &grammar.Literal{Value: "\n"}},
+			&grammar.Not{Expr: &grammar.AnyChar{}},
+		}},
+		{Name: "Record", Expr: grammar.Sequence{
+			&grammar.Ref{Name: "Field"},
+			&grammar.ZeroOrMore{Expr: grammar.Sequence{
+				&grammar.Literal{Value: ","},
+				&grammar.Ref{Name: "Field"},
+			}},
+		}},
+		{Name: "Field", Expr: grammar.Choice{
+			&grammar.Ref{Name: "Quoted"},
+			&grammar.Ref{Name: "Bare"},
+		}},
+		{Name: "Quoted", Expr: grammar.Sequence{
+			&grammar.Literal{Value: `"`},
+			&grammar.ZeroOrMore{Expr: grammar.Choice{
+				&grammar.Class{Pattern: `[^"]`},
+				&grammar.Literal{Value: `""`},
+			}},
+			&grammar.Literal{Value: `"`},
+		}},
+		{Name: "Bare", Expr: &grammar.ZeroOrMore{
+			Expr: &grammar.Class{Pattern: `[^,\n"]`},
+		}},
+	},
+}
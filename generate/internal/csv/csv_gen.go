@@ -0,0 +1,368 @@
+// Code generated by kombigen. DO NOT EDIT.
+
+package csv
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kode4food/kombi/grammar"
+	"github.com/kode4food/kombi/parse"
+)
+
+type recordsParser struct {
+	actions []parse.Mapper
+}
+
+var (
+	recordsPattern0 = regexp.MustCompile("^([^\"])")
+	recordsPattern1 = regexp.MustCompile("^([^,\\n\"])")
+)
+
+// NewRecords returns the generated Parsers, keyed by rule name, binding
+// their actions to the functions in the provided Registry
+func NewRecords(reg *grammar.Registry) (grammar.Parsers, error) {
+	p := &recordsParser{
+		actions: make([]parse.Mapper, 0),
+	}
+	return grammar.Parsers{
+		"File":   p.rule0,
+		"Record": p.rule1,
+		"Field":  p.rule2,
+		"Quoted": p.rule3,
+		"Bare":   p.rule4,
+	}, nil
+}
+
+// expr0 matches: "\n"
+func (p *recordsParser) expr0(i parse.Input) (*parse.Success, *parse.Failure) {
+	if strings.HasPrefix(i.String(), "\n") {
+		return &parse.Success{Result: "\n", Remaining: i.Advance(1)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedString, "\n"),
+		Input: i,
+	}
+}
+
+// expr1 matches: "\n" Record
+func (p *recordsParser) expr1(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.expr0(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule1(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr2 matches: ("\n" Record)*
+func (p *recordsParser) expr2(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr1(i)
+	if f != nil {
+		return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+	}
+	res := parse.Results{}
+	for f == nil {
+		res = p.appendResults(res, s.Result)
+		i = s.Remaining
+		s, f = p.expr1(i)
+	}
+	return &parse.Success{Result: res, Remaining: i}, nil
+}
+
+// expr3 matches: "\n"
+func (p *recordsParser) expr3(i parse.Input) (*parse.Success, *parse.Failure) {
+	if strings.HasPrefix(i.String(), "\n") {
+		return &parse.Success{Result: "\n", Remaining: i.Advance(1)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedString, "\n"),
+		Input: i,
+	}
+}
+
+// expr4 matches: "\n"?
+func (p *recordsParser) expr4(i parse.Input) (*parse.Success, *parse.Failure) {
+	if s, f := p.expr3(i); f == nil {
+		return s, nil
+	}
+	return &parse.Success{Result: nil, Remaining: i}, nil
+}
+
+// expr5 matches: .
+func (p *recordsParser) expr5(i parse.Input) (*parse.Success, *parse.Failure) {
+	if str := i.String(); len(str) > 0 {
+		_, n := utf8.DecodeRuneInString(str)
+		return &parse.Success{Result: str[:n], Remaining: i.Advance(n)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedPattern, "(?s:.)"),
+		Input: i,
+	}
+}
+
+// expr6 matches: !.
+func (p *recordsParser) expr6(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr5(i)
+	if f != nil {
+		return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+	}
+	return nil, &parse.Failure{
+		Error: &parse.Error{
+			Err:  parse.ErrUnexpectedInput,
+			Args: []any{i.Until(s.Remaining)},
+		},
+		Input: i,
+	}
+}
+
+// expr7 matches: Record ("\n" Record)* "\n"? !.
+func (p *recordsParser) expr7(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.rule1(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr2(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr4(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr6(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// rule0 matches: File <- Record ("\n" Record)* "\n"? !.
+func (p *recordsParser) rule0(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr7(i)
+}
+
+// expr8 matches: ","
+func (p *recordsParser) expr8(i parse.Input) (*parse.Success, *parse.Failure) {
+	if strings.HasPrefix(i.String(), ",") {
+		return &parse.Success{Result: ",", Remaining: i.Advance(1)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedString, ","),
+		Input: i,
+	}
+}
+
+// expr9 matches: "," Field
+func (p *recordsParser) expr9(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.expr8(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.rule2(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// expr10 matches: ("," Field)*
+func (p *recordsParser) expr10(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr9(i)
+	if f != nil {
+		return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+	}
+	res := parse.Results{}
+	for f == nil {
+		res = p.appendResults(res, s.Result)
+		i = s.Remaining
+		s, f = p.expr9(i)
+	}
+	return &parse.Success{Result: res, Remaining: i}, nil
+}
+
+// expr11 matches: Field ("," Field)*
+func (p *recordsParser) expr11(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.rule2(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr10(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// rule1 matches: Record <- Field ("," Field)*
+func (p *recordsParser) rule1(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr11(i)
+}
+
+// expr12 matches: Quoted / Bare
+func (p *recordsParser) expr12(i parse.Input) (*parse.Success, *parse.Failure) {
+	if s, f := p.rule3(i); f == nil {
+		return s, nil
+	}
+	return p.rule4(i)
+}
+
+// rule2 matches: Field <- Quoted / Bare
+func (p *recordsParser) rule2(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr12(i)
+}
+
+// expr13 matches: "\""
+func (p *recordsParser) expr13(i parse.Input) (*parse.Success, *parse.Failure) {
+	if strings.HasPrefix(i.String(), "\"") {
+		return &parse.Success{Result: "\"", Remaining: i.Advance(1)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedString, "\""),
+		Input: i,
+	}
+}
+
+// expr14 matches: [^"]
+func (p *recordsParser) expr14(i parse.Input) (*parse.Success, *parse.Failure) {
+	if m := recordsPattern0.FindStringIndex(i.String()); m != nil {
+		return &parse.Success{
+			Result:    i.String()[:m[1]],
+			Remaining: i.Advance(m[1]),
+		}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedPattern, "[^\"]"),
+		Input: i,
+	}
+}
+
+// expr15 matches: "\"\""
+func (p *recordsParser) expr15(i parse.Input) (*parse.Success, *parse.Failure) {
+	if strings.HasPrefix(i.String(), "\"\"") {
+		return &parse.Success{Result: "\"\"", Remaining: i.Advance(2)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedString, "\"\""),
+		Input: i,
+	}
+}
+
+// expr16 matches: [^"] / "\"\""
+func (p *recordsParser) expr16(i parse.Input) (*parse.Success, *parse.Failure) {
+	if s, f := p.expr14(i); f == nil {
+		return s, nil
+	}
+	return p.expr15(i)
+}
+
+// expr17 matches: ([^"] / "\"\"")*
+func (p *recordsParser) expr17(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr16(i)
+	if f != nil {
+		return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+	}
+	res := parse.Results{}
+	for f == nil {
+		res = p.appendResults(res, s.Result)
+		i = s.Remaining
+		s, f = p.expr16(i)
+	}
+	return &parse.Success{Result: res, Remaining: i}, nil
+}
+
+// expr18 matches: "\""
+func (p *recordsParser) expr18(i parse.Input) (*parse.Success, *parse.Failure) {
+	if strings.HasPrefix(i.String(), "\"") {
+		return &parse.Success{Result: "\"", Remaining: i.Advance(1)}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedString, "\""),
+		Input: i,
+	}
+}
+
+// expr19 matches: "\"" ([^"] / "\"\"")* "\""
+func (p *recordsParser) expr19(i parse.Input) (*parse.Success, *parse.Failure) {
+	res := parse.Results{}
+	s, f := p.expr13(i)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr17(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	s, f = p.expr18(s.Remaining)
+	if f != nil {
+		return nil, f
+	}
+	res = p.appendResults(res, s.Result)
+	return &parse.Success{Result: res, Remaining: s.Remaining}, nil
+}
+
+// rule3 matches: Quoted <- "\"" ([^"] / "\"\"")* "\""
+func (p *recordsParser) rule3(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr19(i)
+}
+
+// expr20 matches: [^,\n"]
+func (p *recordsParser) expr20(i parse.Input) (*parse.Success, *parse.Failure) {
+	if m := recordsPattern1.FindStringIndex(i.String()); m != nil {
+		return &parse.Success{
+			Result:    i.String()[:m[1]],
+			Remaining: i.Advance(m[1]),
+		}, nil
+	}
+	return nil, &parse.Failure{
+		Error: i.Expected(parse.ErrExpectedPattern, "[^,\\n\"]"),
+		Input: i,
+	}
+}
+
+// expr21 matches: [^,\n"]*
+func (p *recordsParser) expr21(i parse.Input) (*parse.Success, *parse.Failure) {
+	s, f := p.expr20(i)
+	if f != nil {
+		return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+	}
+	res := parse.Results{}
+	for f == nil {
+		res = p.appendResults(res, s.Result)
+		i = s.Remaining
+		s, f = p.expr20(i)
+	}
+	return &parse.Success{Result: res, Remaining: i}, nil
+}
+
+// rule4 matches: Bare <- [^,\n"]*
+func (p *recordsParser) rule4(i parse.Input) (*parse.Success, *parse.Failure) {
+	return p.expr21(i)
+}
+
+func (*recordsParser) appendResults(res parse.Results, r any) parse.Results {
+	if c, ok := r.(parse.Results); ok {
+		return append(res, c...)
+	}
+	return append(res, r)
+}
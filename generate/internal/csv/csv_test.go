@@ -0,0 +1,75 @@
+package csv_test
+
+import (
+	"testing"
+
+	"github.com/kode4food/kombi/generate/internal/csv"
+	"github.com/kode4food/kombi/grammar"
+	"github.com/kode4food/kombi/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+var inputs = []string{
+	"a,b,c\n1,2,3\n",
+	`"quoted, ""field""",plain`,
+	"a,,b",
+	",",
+	"",
+	`"unterminated`,
+	`bad"quote`,
+}
+
+func TestGenerated(t *testing.T) {
+	as := assert.New(t)
+
+	compiled, err := csv.Grammar.Compile(grammar.NewRegistry())
+	as.Nil(err)
+	generated, err := csv.NewRecords(grammar.NewRegistry())
+	as.Nil(err)
+	as.Equal(len(compiled), len(generated))
+
+	for name, c := range compiled {
+		gen := generated[name]
+		for _, in := range inputs {
+			cs, cf := c.Parse(in)
+			gs, gf := gen.Parse(in)
+			if cf == nil {
+				as.Nil(gf)
+				as.Equal(cs.Result, gs.Result, "rule %s, input %q", name, in)
+				as.Equal(cs.Remaining.Offset(), gs.Remaining.Offset())
+				continue
+			}
+			as.Nil(gs)
+			as.Equal(cf.Input.Offset(), gf.Input.Offset(),
+				"rule %s, input %q", name, in,
+			)
+			as.EqualError(gf.Error, cf.Error.Error())
+		}
+	}
+}
+
+func TestEmptyMatch(t *testing.T) {
+	as := assert.New(t)
+
+	compiled, err := csv.Grammar.Compile(grammar.NewRegistry())
+	as.Nil(err)
+	generated, err := csv.NewRecords(grammar.NewRegistry())
+	as.Nil(err)
+
+	cs, cf := compiled["Bare"].Parse(",")
+	gs, gf := generated["Bare"].Parse(",")
+	as.Nil(cf)
+	as.Nil(gf)
+	as.Equal(parse.Results{}, cs.Result)
+	as.Equal(cs.Result, gs.Result)
+
+	cs, _ = compiled["Record"].Parse("")
+	gs, _ = generated["Record"].Parse("")
+	as.Equal(parse.Results{}, cs.Result)
+	as.Equal(cs.Result, gs.Result)
+
+	cs, _ = compiled["Record"].Parse(",")
+	gs, _ = generated["Record"].Parse(",")
+	as.Equal(parse.Results{","}, cs.Result)
+	as.Equal(cs.Result, gs.Result)
+}
@@ -0,0 +1,65 @@
+package generate
+
+// Method bodies for the generated repetition and lookahead expressions.
+// CHILD is replaced with the name of the wrapped expression's method
+const (
+	zeroOrMore = `	s, f := p.CHILD(i)
+	if f != nil {
+		return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+	}
+	res := parse.Results{}
+	for f == nil {
+		res = p.appendResults(res, s.Result)
+		i = s.Remaining
+		s, f = p.CHILD(i)
+	}
+	return &parse.Success{Result: res, Remaining: i}, nil
+`
+
+	oneOrMore = `	s, f := p.CHILD(i)
+	if f != nil {
+		return nil, f
+	}
+	res := parse.Results{}
+	for f == nil {
+		res = p.appendResults(res, s.Result)
+		i = s.Remaining
+		s, f = p.CHILD(i)
+	}
+	return &parse.Success{Result: res, Remaining: i}, nil
+`
+
+	optional = `	if s, f := p.CHILD(i); f == nil {
+		return s, nil
+	}
+	return &parse.Success{Result: nil, Remaining: i}, nil
+`
+
+	and = `	if _, f := p.CHILD(i); f != nil {
+		return nil, f
+	}
+	return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+`
+
+	not = `	s, f := p.CHILD(i)
+	if f != nil {
+		return &parse.Success{Result: parse.Results{}, Remaining: i}, nil
+	}
+	return nil, &parse.Failure{
+		Error: &parse.Error{
+			Err:  parse.ErrUnexpectedInput,
+			Args: []any{i.Until(s.Remaining)},
+		},
+		Input: i,
+	}
+`
+)
+
+const appendResults = `
+func (*%s) appendResults(res parse.Results, r any) parse.Results {
+	if c, ok := r.(parse.Results); ok {
+		return append(res, c...)
+	}
+	return append(res, r)
+}
+`
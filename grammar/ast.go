@@ -0,0 +1,189 @@
+package grammar
+
+import (
+	"strconv"
+	"strings"
+)
+
+type (
+	// Grammar is a set of named Rules parsed from PEG grammar text. The
+	// first Rule is the starting Rule
+	Grammar struct {
+		Rules []*Rule
+	}
+
+	// Rule associates a name with the Expr that it matches
+	Rule struct {
+		Name string
+		Expr Expr
+	}
+
+	// Expr is a node in a Rule's expression tree
+	Expr interface {
+		String() string
+	}
+
+	// Choice matches the first of its alternatives that succeeds
+	Choice []Expr
+
+	// Sequence matches each of its Exprs in succession
+	Sequence []Expr
+
+	// Action passes the result of its Expr to a registered function
+	Action struct {
+		Expr Expr
+		Name string
+	}
+
+	// ZeroOrMore matches its Expr zero or more times
+	ZeroOrMore struct {
+		Expr Expr
+	}
+
+	// OneOrMore matches its Expr one or more times
+	OneOrMore struct {
+		Expr Expr
+	}
+
+	// Optional matches its Expr zero or one time
+	Optional struct {
+		Expr Expr
+	}
+
+	// And succeeds if its Expr matches, without consuming any Input
+	And struct {
+		Expr Expr
+	}
+
+	// Not succeeds if its Expr doesn't match, without consuming any Input
+	Not struct {
+		Expr Expr
+	}
+
+	// Ref matches the Rule having the specified name
+	Ref struct {
+		Name string
+	}
+
+	// Literal matches a string, possibly ignoring case
+	Literal struct {
+		Value      string
+		IgnoreCase bool
+	}
+
+	// Class matches a single character from a regular expression character
+	// class, such as [a-z]
+	Class struct {
+		Pattern    string
+		IgnoreCase bool
+	}
+
+	// AnyChar matches any single character
+	AnyChar struct{}
+)
+
+// Start returns the name of the starting Rule
+func (g *Grammar) Start() string {
+	return g.Rules[0].Name
+}
+
+// Rule returns the Rule having the specified name
+func (g *Grammar) Rule(name string) (*Rule, bool) {
+	for _, r := range g.Rules {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+func (g *Grammar) String() string {
+	res := make([]string, len(g.Rules))
+	for i, r := range g.Rules {
+		res[i] = r.String()
+	}
+	return strings.Join(res, "\n")
+}
+
+func (r *Rule) String() string {
+	return r.Name + " <- " + r.Expr.String()
+}
+
+func (c Choice) String() string {
+	res := make([]string, len(c))
+	for i, e := range c {
+		if _, ok := e.(Choice); ok {
+			res[i] = "(" + e.String() + ")"
+			continue
+		}
+		res[i] = e.String()
+	}
+	return strings.Join(res, " / ")
+}
+
+func (s Sequence) String() string {
+	res := make([]string, len(s))
+	for i, e := range s {
+		res[i] = group(e)
+	}
+	return strings.Join(res, " ")
+}
+
+func (a *Action) String() string {
+	if _, ok := a.Expr.(Choice); ok {
+		return "(" + a.Expr.String() + ") {" + a.Name + "}"
+	}
+	return a.Expr.String() + " {" + a.Name + "}"
+}
+
+func (z *ZeroOrMore) String() string {
+	return group(z.Expr) + "*"
+}
+
+func (o *OneOrMore) String() string {
+	return group(o.Expr) + "+"
+}
+
+func (o *Optional) String() string {
+	return group(o.Expr) + "?"
+}
+
+func (a *And) String() string {
+	return "&" + group(a.Expr)
+}
+
+func (n *Not) String() string {
+	return "!" + group(n.Expr)
+}
+
+func (r *Ref) String() string {
+	return r.Name
+}
+
+func (l *Literal) String() string {
+	res := strconv.Quote(l.Value)
+	if l.IgnoreCase {
+		return res + "i"
+	}
+	return res
+}
+
+func (c *Class) String() string {
+	if c.IgnoreCase {
+		return c.Pattern + "i"
+	}
+	return c.Pattern
+}
+
+func (*AnyChar) String() string {
+	return "."
+}
+
+func group(e Expr) string {
+	switch e.(type) {
+	case Choice, Sequence, *Action:
+		return "(" + e.String() + ")"
+	default:
+		return e.String()
+	}
+}
@@ -0,0 +1,211 @@
+package grammar
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/kode4food/kombi/cst"
+	"github.com/kode4food/kombi/parse"
+)
+
+type (
+	// Parsers maps Rule names to their compiled Parsers
+	Parsers map[string]parse.Parser
+
+	compiler struct {
+		grammar *Grammar
+		reg     *Registry
+		cst     *cst.Builder
+		parsers Parsers
+	}
+)
+
+// Error messages
+const (
+	ErrUndefinedRule = "rule %s is not defined"
+	ErrUnknownExpr   = "unknown expression type: %T"
+)
+
+// Load reads PEG grammar text from the provided Reader, compiles it using the
+// provided Registry, and returns the Parser for its starting Rule
+func Load(r io.Reader, reg *Registry) (parse.Parser, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	g, err := Parse(string(src))
+	if err != nil {
+		return nil, err
+	}
+	p, err := g.Compile(reg)
+	if err != nil {
+		return nil, err
+	}
+	return p[g.Start()], nil
+}
+
+// Compile compiles each of the Grammar's Rules into a Parser, binding any
+// named actions to the functions in the provided Registry
+func (g *Grammar) Compile(reg *Registry) (Parsers, error) {
+	return (&compiler{
+		grammar: g,
+		reg:     reg,
+	}).compileRules()
+}
+
+// CompileCST compiles each of the Grammar's Rules into a Parser that
+// produces concrete syntax tree Nodes instead of performing actions. Each
+// Rule produces a Node named after it, and each literal, class, or any
+// character match produces a token that is surrounded by whatever the
+// provided trivia Parser matches. Rules are memoized, so that an Input
+// that has been Edited can be parsed again incrementally
+func (g *Grammar) CompileCST(trivia parse.Parser) (Parsers, error) {
+	return (&compiler{
+		grammar: g,
+		cst:     cst.NewBuilder(trivia),
+	}).compileRules()
+}
+
+func (c *compiler) compileRules() (Parsers, error) {
+	c.parsers = make(Parsers, len(c.grammar.Rules))
+	for _, r := range c.grammar.Rules {
+		p, err := c.compile(r.Expr)
+		if err != nil {
+			return nil, err
+		}
+		if c.cst != nil {
+			p = c.cst.Rule(r.Name, p).Memo()
+		}
+		c.parsers[r.Name] = p
+	}
+	return c.parsers, nil
+}
+
+func (c *compiler) compile(e Expr) (parse.Parser, error) {
+	p, err := c.compileExpr(e)
+	if err != nil || c.cst == nil {
+		return p, err
+	}
+	switch e.(type) {
+	case *Literal, *Class, *AnyChar:
+		return c.cst.Token(e.String(), p), nil
+	default:
+		return p, nil
+	}
+}
+
+func (c *compiler) compileExpr(e Expr) (parse.Parser, error) {
+	switch e := e.(type) {
+	case Choice:
+		return c.choice(e)
+	case Sequence:
+		return c.sequence(e)
+	case *Action:
+		return c.action(e)
+	case *ZeroOrMore:
+		return c.wrap(e.Expr, parse.ZeroOrMore)
+	case *OneOrMore:
+		return c.wrap(e.Expr, parse.OneOrMore)
+	case *Optional:
+		return c.wrap(e.Expr, parse.Optional)
+	case *And:
+		return c.wrap(e.Expr, peek)
+	case *Not:
+		return c.wrap(e.Expr, notFollowedBy)
+	case *Ref:
+		return c.ref(e)
+	case *Literal:
+		if e.IgnoreCase {
+			return parse.StrCaseCmp(e.Value), nil
+		}
+		return parse.String(e.Value), nil
+	case *Class:
+		return c.class(e)
+	case *AnyChar:
+		return parse.RegExp("(?s:.)"), nil
+	default:
+		return nil, fmt.Errorf(ErrUnknownExpr, e)
+	}
+}
+
+func (c *compiler) choice(e Choice) (parse.Parser, error) {
+	res := make([]parse.Parser, len(e))
+	for i, a := range e {
+		p, err := c.compile(a)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = p
+	}
+	return parse.Any(res[0], res[1:]...), nil
+}
+
+func (c *compiler) sequence(e Sequence) (parse.Parser, error) {
+	var res parse.Parser
+	for _, s := range e {
+		p, err := c.compile(s)
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			res = p
+			continue
+		}
+		res = res.Concat(p)
+	}
+	return res, nil
+}
+
+func (c *compiler) action(e *Action) (parse.Parser, error) {
+	p, err := c.compile(e.Expr)
+	if err != nil || c.cst != nil {
+		return p, err
+	}
+	fn, err := c.reg.Action(e.Name)
+	if err != nil {
+		return nil, err
+	}
+	return p.Map(fn), nil
+}
+
+func (c *compiler) class(e *Class) (parse.Parser, error) {
+	pattern := e.Pattern
+	if e.IgnoreCase {
+		pattern = "(?i:" + pattern + ")"
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, err
+	}
+	return parse.RegExp(pattern), nil
+}
+
+func (c *compiler) wrap(
+	e Expr, fn func(parse.Parser) parse.Parser,
+) (parse.Parser, error) {
+	p, err := c.compile(e)
+	if err != nil {
+		return nil, err
+	}
+	return fn(p), nil
+}
+
+func (c *compiler) ref(e *Ref) (parse.Parser, error) {
+	if _, ok := c.grammar.Rule(e.Name); !ok {
+		return nil, fmt.Errorf(ErrUndefinedRule, e.Name)
+	}
+	name := e.Name
+	return func(i parse.Input) (*parse.Success, *parse.Failure) {
+		return c.parsers[name](i)
+	}, nil
+}
+
+// peek and notFollowedBy produce empty Results so that lookahead disappears
+// when Concatenated into a Sequence
+func peek(p parse.Parser) parse.Parser {
+	return parse.Peek(p).Return(parse.Results{})
+}
+
+func notFollowedBy(p parse.Parser) parse.Parser {
+	return parse.NotFollowedBy(p).Return(parse.Results{})
+}
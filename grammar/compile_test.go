@@ -0,0 +1,200 @@
+package grammar_test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kode4food/kombi/cst"
+	"github.com/kode4food/kombi/grammar"
+	"github.com/kode4food/kombi/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+const calc = `
+	Sum     <- Product (AddOp Product)* {fold}
+	Product <- Value (MulOp Value)* {fold}
+	Value   <- Number / "(" _ Sum ")" _ {group}
+	Number  <- [0-9]+ _ {int}
+	AddOp   <- [+-] _ {op}
+	MulOp   <- [*/] _ {op}
+	_       <- [ \t]*
+`
+
+func calcRegistry() *grammar.Registry {
+	return grammar.NewRegistry().
+		Combiner("int", func(r ...any) any {
+			var buf strings.Builder
+			for _, e := range r {
+				buf.WriteString(e.(string))
+			}
+			res, _ := strconv.Atoi(strings.TrimSpace(buf.String()))
+			return res
+		}).
+		Mapper("op", func(r any) any {
+			return r.(parse.Results)[0]
+		}).
+		Combiner("group", func(r ...any) any {
+			for _, e := range r {
+				if i, ok := e.(int); ok {
+					return i
+				}
+			}
+			return nil
+		}).
+		Combiner("fold", func(r ...any) any {
+			res := r[0].(int)
+			for i := 1; i < len(r); i += 2 {
+				v := r[i+1].(int)
+				switch r[i] {
+				case "+":
+					res += v
+				case "-":
+					res -= v
+				case "*":
+					res *= v
+				case "/":
+					res /= v
+				}
+			}
+			return res
+		})
+}
+
+func TestLoad(t *testing.T) {
+	as := assert.New(t)
+
+	p, err := grammar.Load(strings.NewReader(calc), calcRegistry())
+	as.Nil(err)
+
+	s, f := p.Parse("2 + 3 * (10 - 4)")
+	as.Nil(f)
+	as.Equal(20, s.Result)
+
+	s, f = p.Parse("(1+2")
+	as.Nil(s)
+	as.EqualError(f.Error, "expected string ), got ")
+	as.ErrorIs(f.Error, parse.ErrExpectedString)
+}
+
+func TestCompileRules(t *testing.T) {
+	as := assert.New(t)
+
+	g, err := grammar.Parse(`
+		Ident   <- !Keyword [a-z]+
+		Keyword <- ("if"i / "else") ![a-z]
+		Hello   <- &"h" .+
+	`)
+	as.Nil(err)
+	p, err := g.Compile(nil)
+	as.Nil(err)
+
+	s, f := p["Ident"].Parse("iffy")
+	as.Nil(f)
+	as.Equal(parse.Results{"i", "f", "f", "y"}, s.Result)
+
+	s, f = p["Ident"].Parse("IF x")
+	as.Nil(s)
+	as.EqualError(f.Error, "unexpected input: IF")
+	as.ErrorIs(f.Error, parse.ErrUnexpectedInput)
+
+	s, f = p["Keyword"].Parse("else x")
+	as.Nil(f)
+	as.Equal(" x", s.Remaining.String())
+
+	s, f = p["Hello"].Parse("hi!")
+	as.Nil(f)
+	as.Equal(parse.Results{"h", "i", "!"}, s.Result)
+
+	s, f = p["Hello"].Parse("bye")
+	as.Nil(s)
+	as.NotNil(f)
+}
+
+func TestCompileErrors(t *testing.T) {
+	as := assert.New(t)
+
+	g, _ := grammar.Parse(`A <- B`)
+	_, err := g.Compile(nil)
+	as.EqualError(err, fmt.Sprintf(grammar.ErrUndefinedRule, "B"))
+
+	g, _ = grammar.Parse(`A <- "a" {missing}`)
+	_, err = g.Compile(grammar.NewRegistry())
+	as.EqualError(err, fmt.Sprintf(grammar.ErrUnknownAction, "missing"))
+
+	g, _ = grammar.Parse(`A <- [z-a]`)
+	_, err = g.Compile(nil)
+	as.NotNil(err)
+
+	_, err = grammar.Load(strings.NewReader(`A <-`), nil)
+	as.NotNil(err)
+}
+
+func TestCompileCST(t *testing.T) {
+	as := assert.New(t)
+
+	g, err := grammar.Parse(`
+		List <- Item ("," Item)* !. {ignored}
+		Item <- [a-z]+
+	`)
+	as.Nil(err)
+	p, err := g.CompileCST(parse.RegExp(`\s+`))
+	as.Nil(err)
+
+	src := " ab ,\n c , d  "
+	s, f := p["List"].Parse(src)
+	as.Nil(f)
+
+	n := s.Result.(*cst.Node)
+	as.Equal("List", n.Kind)
+	as.Equal(src, n.String())
+	as.Equal(5, len(n.Children()))
+	as.Equal("Item", n.Children()[0].Kind)
+	as.Equal(`","`, n.Children()[1].Kind)
+	as.Equal(",\n ", n.Children()[1].String())
+	as.Equal(parse.Span{Start: 1, End: 12}, n.Span())
+}
+
+func TestCompileCSTEdit(t *testing.T) {
+	as := assert.New(t)
+
+	g, err := grammar.Parse(`
+		List <- Item ("," Item)* !.
+		Item <- [a-z]+
+	`)
+	as.Nil(err)
+	p, err := g.CompileCST(parse.RegExp(`\s+`))
+	as.Nil(err)
+
+	i := parse.NewInput("ab, c, d, ef")
+	s, f := p["List"](i)
+	as.Nil(f)
+	first := s.Result.(*cst.Node)
+
+	i = i.Edit(parse.Edit{Start: 7, End: 8, Text: "xyz"})
+	s, f = p["List"](i)
+	as.Nil(f)
+	edited := s.Result.(*cst.Node)
+	as.Equal("ab, c, xyz, ef", edited.String())
+	as.Same(first.Children()[0], edited.Children()[0])
+
+	fresh, f := p["List"].Parse("ab, c, xyz, ef")
+	as.Nil(f)
+	as.Equal(describe(fresh.Result.(*cst.Node)), describe(edited))
+}
+
+func describe(n *cst.Node) []string {
+	res := []string{
+		fmt.Sprintf("%s %v %v %q", n.Kind, n.Span(), n.FullSpan(), n.Text),
+	}
+	for _, t := range append(n.Leading(), n.Trailing()...) {
+		res = append(res, fmt.Sprintf("  trivia %v %q", t.Span, t.Text))
+	}
+	for _, c := range n.Children() {
+		for _, d := range describe(c) {
+			res = append(res, "  "+d)
+		}
+	}
+	return res
+}
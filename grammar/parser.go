@@ -0,0 +1,192 @@
+package grammar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+// Error messages
+const (
+	ErrDuplicateRule = "rule %s is defined more than once"
+	ErrSyntax        = "syntax error at %s: %w"
+)
+
+var grammarParser = makeGrammarParser()
+
+// Parse parses PEG grammar text into a Grammar. Rules take the form
+// `Name <- Expr` (or `Name = Expr`), and may optionally be terminated by a
+// semicolon. Expressions support ordered choice (/), sequences, grouping,
+// repetition (*, +, ?), lookahead (&, !), string literals ("..." or '...',
+// with an `i` suffix for case-insensitivity), character classes ([a-z]), any
+// character (.), and named actions ({name}) that are bound using a Registry.
+// A syntax error reports the line and column where it was found
+func Parse(src string) (*Grammar, error) {
+	s, f := grammarParser.Parse(src)
+	if f != nil {
+		return nil, fmt.Errorf(ErrSyntax, f.Input.Position(), f.Error)
+	}
+	g := &Grammar{}
+	for _, r := range s.Result.(parse.Results) {
+		r := r.(*Rule)
+		if _, ok := g.Rule(r.Name); ok {
+			return nil, fmt.Errorf(ErrDuplicateRule, r.Name)
+		}
+		g.Rules = append(g.Rules, r)
+	}
+	return g, nil
+}
+
+func makeGrammarParser() parse.Parser {
+	ws := parse.RegExp(`(\s|#[^\n]*)*`)
+
+	token := func(p parse.Parser) parse.Parser {
+		return p.Bind(func(r any) parse.Parser {
+			return ws.Return(r)
+		})
+	}
+
+	symbol := func(s string) parse.Parser {
+		return token(parse.String(s))
+	}
+
+	ident := token(parse.RegExp(`[A-Za-z_][A-Za-z0-9_]*`))
+	arrow := parse.Any(symbol("<-"), symbol("="))
+
+	var expr parse.Parser
+	exprRef := parse.Parser(func(i parse.Input) (*parse.Success, *parse.Failure) {
+		return expr(i)
+	})
+
+	literal := token(parse.Or(
+		parse.RegExp(`"(\\[nrt\\"']|[^"\\])*"i?`),
+		parse.RegExp(`'(\\[nrt\\"']|[^'\\])*'i?`),
+	)).Map(func(r any) any {
+		s := r.(string)
+		if strings.HasSuffix(s, "i") {
+			return &Literal{
+				Value:      unescape(s[1 : len(s)-2]),
+				IgnoreCase: true,
+			}
+		}
+		return &Literal{Value: unescape(s[1 : len(s)-1])}
+	})
+
+	class := token(parse.RegExp(`\[(\\.|[^\]\\])*\]i?`)).Map(func(r any) any {
+		s := r.(string)
+		if strings.HasSuffix(s, "i") {
+			return &Class{Pattern: s[:len(s)-1], IgnoreCase: true}
+		}
+		return &Class{Pattern: s}
+	})
+
+	ref := ident.NotBefore(arrow).Map(func(r any) any {
+		return &Ref{Name: r.(string)}
+	})
+
+	primary := parse.Any(
+		ref,
+		symbol("(").Then(exprRef).Bind(func(r any) parse.Parser {
+			return symbol(")").Return(r)
+		}),
+		literal,
+		class,
+		symbol(".").Return(&AnyChar{}),
+	)
+
+	suffix := primary.Bind(func(r any) parse.Parser {
+		e := r.(Expr)
+		return parse.Any(
+			symbol("*").Return(&ZeroOrMore{Expr: e}),
+			symbol("+").Return(&OneOrMore{Expr: e}),
+			symbol("?").Return(&Optional{Expr: e}),
+			parse.Return(e),
+		)
+	})
+
+	prefix := parse.Any(
+		symbol("&").Then(suffix).Map(func(r any) any {
+			return &And{Expr: r.(Expr)}
+		}),
+		symbol("!").Then(suffix).Map(func(r any) any {
+			return &Not{Expr: r.(Expr)}
+		}),
+		suffix,
+	)
+
+	action := symbol("{").Then(ident).Bind(func(r any) parse.Parser {
+		return symbol("}").Return(r)
+	})
+
+	seq := prefix.OneOrMore().Bind(func(r any) parse.Parser {
+		var e Expr
+		if res := r.(parse.Results); len(res) == 1 {
+			e = res[0].(Expr)
+		} else {
+			e = toSequence(res)
+		}
+		return parse.Or(
+			action.Map(func(r any) any {
+				return &Action{Expr: e, Name: r.(string)}
+			}),
+			parse.Return(e),
+		)
+	})
+
+	expr = seq.Delimited(symbol("/")).Map(func(r any) any {
+		res := r.(parse.Results)
+		if len(res) == 1 {
+			return res[0]
+		}
+		c := make(Choice, len(res))
+		for i, e := range res {
+			c[i] = e.(Expr)
+		}
+		return c
+	})
+
+	rule := ident.Bind(func(n any) parse.Parser {
+		return arrow.Then(expr).Bind(func(e any) parse.Parser {
+			return symbol(";").Optional().Return(&Rule{
+				Name: n.(string),
+				Expr: e.(Expr),
+			})
+		})
+	})
+
+	return ws.Then(rule.OneOrMore()).Bind(func(r any) parse.Parser {
+		return parse.EOF.Return(r)
+	})
+}
+
+func toSequence(r parse.Results) Sequence {
+	res := make(Sequence, len(r))
+	for i, e := range r {
+		res[i] = e.(Expr)
+	}
+	return res
+}
+
+func unescape(s string) string {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' {
+			buf.WriteByte(c)
+			continue
+		}
+		i++
+		switch e := s[i]; e {
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 't':
+			buf.WriteByte('\t')
+		default:
+			buf.WriteByte(e)
+		}
+	}
+	return buf.String()
+}
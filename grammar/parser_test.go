@@ -0,0 +1,75 @@
+package grammar_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/kombi/grammar"
+	"github.com/kode4food/kombi/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	as := assert.New(t)
+
+	g, err := grammar.Parse(`
+		# a simple list of words
+		List  <- Word ("," Word)* !. {list}
+		Word  <- [a-z]+ / 'QUOTED'i;
+	`)
+	as.Nil(err)
+	as.Equal("List", g.Start())
+	as.Equal(2, len(g.Rules))
+	as.Equal(
+		`List <- Word ("," Word)* !. {list}`+"\n"+
+			`Word <- [a-z]+ / "QUOTED"i`,
+		g.String(),
+	)
+
+	r, ok := g.Rule("Word")
+	as.True(ok)
+	as.Equal(grammar.Choice{
+		&grammar.OneOrMore{Expr: &grammar.Class{Pattern: "[a-z]"}},
+		&grammar.Literal{Value: "QUOTED", IgnoreCase: true},
+	}, r.Expr)
+
+	_, ok = g.Rule("Missing")
+	as.False(ok)
+}
+
+func TestParseOperators(t *testing.T) {
+	as := assert.New(t)
+
+	g, err := grammar.Parse(`S = &"a" "\t\n" .? (S / 'x')+ [\]]i`)
+	as.Nil(err)
+	as.Equal(grammar.Sequence{
+		&grammar.And{Expr: &grammar.Literal{Value: "a"}},
+		&grammar.Literal{Value: "\t\n"},
+		&grammar.Optional{Expr: &grammar.AnyChar{}},
+		&grammar.OneOrMore{Expr: grammar.Choice{
+			&grammar.Ref{Name: "S"},
+			&grammar.Literal{Value: "x"},
+		}},
+		&grammar.Class{Pattern: `[\]]`, IgnoreCase: true},
+	}, g.Rules[0].Expr)
+}
+
+func TestParseErrors(t *testing.T) {
+	as := assert.New(t)
+
+	_, err := grammar.Parse(`A <- "a"` + "\n" + `A <- "b"`)
+	as.EqualError(err, fmt.Sprintf(grammar.ErrDuplicateRule, "A"))
+
+	_, err = grammar.Parse(`A <- "\q"`)
+	as.NotNil(err)
+
+	_, err = grammar.Parse(`A <- "a" )`)
+	as.NotNil(err)
+
+	_, err = grammar.Parse("A <- \"a\"\nB <- \"b\" )")
+	as.ErrorIs(err, parse.ErrExpectedEndOfFile)
+	as.EqualError(err, "syntax error at 2:10: expected end of file, got )")
+
+	_, err = grammar.Parse(``)
+	as.NotNil(err)
+}
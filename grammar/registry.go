@@ -0,0 +1,74 @@
+package grammar
+
+import (
+	"fmt"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+// Registry maps the action names that appear in a Grammar to the Mapper and
+// Combiner functions that implement them
+type Registry struct {
+	mappers   map[string]parse.Mapper
+	combiners map[string]parse.Combiner
+}
+
+// Error messages
+const (
+	ErrUnknownAction = "action %s is not registered"
+)
+
+// NewRegistry returns a new, empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		mappers:   map[string]parse.Mapper{},
+		combiners: map[string]parse.Combiner{},
+	}
+}
+
+// Mapper registers a Mapper under the provided action name. The Mapper
+// receives the result of the action's expression
+func (r *Registry) Mapper(name string, fn parse.Mapper) *Registry {
+	r.mappers[name] = fn
+	return r
+}
+
+// Combiner registers a Combiner under the provided action name. The
+// Combiner receives the Combined results of the action's expression
+func (r *Registry) Combiner(name string, fn parse.Combiner) *Registry {
+	r.combiners[name] = fn
+	return r
+}
+
+// Action returns a Mapper that performs the named action. If the action was
+// registered as a Combiner, the Mapper passes it any Combined results
+func (r *Registry) Action(name string) (parse.Mapper, error) {
+	if fn, ok := r.combiner(name); ok {
+		return func(r any) any {
+			if res, ok := r.(parse.Results); ok {
+				return fn(res...)
+			}
+			return fn(r)
+		}, nil
+	}
+	if fn, ok := r.mapper(name); ok {
+		return fn, nil
+	}
+	return nil, fmt.Errorf(ErrUnknownAction, name)
+}
+
+func (r *Registry) mapper(name string) (parse.Mapper, bool) {
+	if r == nil {
+		return nil, false
+	}
+	fn, ok := r.mappers[name]
+	return fn, ok
+}
+
+func (r *Registry) combiner(name string) (parse.Combiner, bool) {
+	if r == nil {
+		return nil, false
+	}
+	fn, ok := r.combiners[name]
+	return fn, ok
+}
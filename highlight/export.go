@@ -0,0 +1,73 @@
+package highlight
+
+import (
+	"html"
+	"io"
+	"strings"
+)
+
+// Theme maps Token classes to the ANSI Select Graphic Rendition parameters
+// that color them, such as "1;34" for bold blue
+type Theme map[string]string
+
+// DefaultTheme colors the Token classes that are most commonly used
+var DefaultTheme = Theme{
+	"comment":  "2",
+	"function": "33",
+	"keyword":  "1;34",
+	"number":   "35",
+	"operator": "33",
+	"string":   "32",
+	"type":     "36",
+	"variable": "36",
+}
+
+const (
+	ansiReset = "\x1b[0m"
+	htmlClose = "</span>"
+)
+
+// ANSI writes the source text to the provided Writer, coloring each of its
+// Tokens according to the Theme. Tokens whose classes the Theme doesn't
+// include are written without color
+func ANSI(w io.Writer, src string, tokens []Token, t Theme) error {
+	return export(w, src, tokens, func(s string) string {
+		return s
+	}, func(class string) (string, string) {
+		if sgr, ok := t[class]; ok {
+			return "\x1b[" + sgr + "m", ansiReset
+		}
+		return "", ""
+	})
+}
+
+// HTML writes the source text to the provided Writer as escaped HTML,
+// wrapping each of its Tokens in a span element whose class attribute is
+// the Token's class
+func HTML(w io.Writer, src string, tokens []Token) error {
+	return export(w, src, tokens, html.EscapeString, func(class string) (string, string) {
+		return `<span class="` + html.EscapeString(class) + `">`, htmlClose
+	})
+}
+
+func export(
+	w io.Writer, src string, tokens []Token,
+	escape func(string) string, wrap func(string) (string, string),
+) error {
+	var buf strings.Builder
+	pos := 0
+	for _, t := range tokens {
+		if t.Span.Start < pos || t.Span.End > len(src) {
+			continue
+		}
+		buf.WriteString(escape(src[pos:t.Span.Start]))
+		open, close := wrap(t.Class)
+		buf.WriteString(open)
+		buf.WriteString(escape(src[t.Span.Start:t.Span.End]))
+		buf.WriteString(close)
+		pos = t.Span.End
+	}
+	buf.WriteString(escape(src[pos:]))
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
@@ -0,0 +1,56 @@
+package highlight_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kode4food/kombi/highlight"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestANSI(t *testing.T) {
+	as := assert.New(t)
+	var buf strings.Builder
+	err := highlight.ANSI(&buf, "let x = 1", []highlight.Token{
+		token(0, 3, "keyword"),
+		token(4, 5, "unknown"),
+		token(8, 9, "number"),
+	}, highlight.DefaultTheme)
+	as.Nil(err)
+	as.Equal("\x1b[1;34mlet\x1b[0m x = \x1b[35m1\x1b[0m", buf.String())
+}
+
+func TestHTML(t *testing.T) {
+	as := assert.New(t)
+	var buf strings.Builder
+	err := highlight.HTML(&buf, `a < "b&c"`, []highlight.Token{
+		token(0, 1, "variable"),
+		token(4, 9, "string"),
+		token(6, 12, "overlapping"),
+	})
+	as.Nil(err)
+	as.Equal(
+		`<span class="variable">a</span> &lt; `+
+			`<span class="string">&#34;b&amp;c&#34;</span>`,
+		buf.String(),
+	)
+}
+
+func TestHighlightConfig(t *testing.T) {
+	as := assert.New(t)
+	toks, err := highlight.Parse(configParser(t), classes, config)
+	as.Nil(err)
+	var buf strings.Builder
+	as.Nil(highlight.HTML(&buf, config, toks))
+	as.Equal(
+		"<span class=\"comment\"># settings</span>\n"+
+			"<span class=\"variable\">port</span> "+
+			"<span class=\"operator\">=</span> "+
+			"<span class=\"number\">80</span>\n"+
+			"<span class=\"variable\">name</span> "+
+			"<span class=\"operator\">=</span> "+
+			"<span class=\"string\">&#34;x&#34;</span> "+
+			"<span class=\"comment\"># trailing</span>\n",
+		buf.String(),
+	)
+}
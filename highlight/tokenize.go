@@ -0,0 +1,92 @@
+// Package highlight classifies the text of concrete syntax trees for
+// syntax highlighting, and exports classified text as ANSI-colored terminal
+// output or HTML. Lexemes are labeled by mapping the kinds of the Nodes
+// that match them to highlighting classes, so the same grammar that parses
+// a document can also highlight it
+package highlight
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kode4food/kombi/cst"
+	"github.com/kode4food/kombi/parse"
+)
+
+type (
+	// Token is a classified Span of source text
+	Token struct {
+		Span  parse.Span
+		Class string
+	}
+
+	// Classes maps the kinds of concrete syntax tree Nodes to the classes
+	// of the Tokens that they produce
+	Classes map[string]string
+)
+
+// TriviaKind is the kind under which Classes may classify the trivia that
+// surrounds tokens. Trivia that consists only of whitespace is never
+// classified
+const TriviaKind = "trivia"
+
+// Error messages
+const (
+	ErrNotNode = "parser produced %T, not *cst.Node"
+)
+
+// Tokenize returns the classified Tokens of the provided concrete syntax
+// tree in source order. A Node whose kind is classified produces a single
+// Token that covers it, excluding its surrounding trivia, while the Nodes
+// beneath it are ignored. Unclassified text produces no Tokens
+func Tokenize(n *cst.Node, c Classes) []Token {
+	trivia, classifyTrivia := c[TriviaKind]
+	var res []Token
+	addTrivia := func(t []*cst.Trivia) {
+		if !classifyTrivia {
+			return
+		}
+		for _, e := range t {
+			if strings.TrimSpace(e.Text) != "" {
+				res = append(res, Token{Span: e.Span, Class: trivia})
+			}
+		}
+	}
+	var walk func(n *cst.Node)
+	walk = func(n *cst.Node) {
+		if class, ok := c[n.Kind]; ok && n.Span().Len() > 0 {
+			toks := n.Tokens()
+			addTrivia(toks[0].Leading())
+			res = append(res, Token{Span: n.Span(), Class: class})
+			addTrivia(toks[len(toks)-1].Trailing())
+			return
+		}
+		addTrivia(n.Leading())
+		for _, child := range n.Children() {
+			walk(child)
+		}
+		addTrivia(n.Trailing())
+	}
+	walk(n)
+	return res
+}
+
+// Parse uses the provided Parser, which must produce a *cst.Node, to parse
+// the source text and returns its classified Tokens. If the Parser fails,
+// the Tokens of any partial result are returned along with the Failure's
+// error
+func Parse(p parse.Parser, c Classes, src string) ([]Token, error) {
+	r := p.Diagnose(src)
+	var err error
+	if r.HasErrors() {
+		err = r.Diagnostics[len(r.Diagnostics)-1].Error
+	}
+	if r.Result == nil {
+		return nil, err
+	}
+	n, ok := r.Result.(*cst.Node)
+	if !ok {
+		return nil, fmt.Errorf(ErrNotNode, r.Result)
+	}
+	return Tokenize(n, c), err
+}
@@ -0,0 +1,79 @@
+package highlight_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/kombi/grammar"
+	"github.com/kode4food/kombi/highlight"
+	"github.com/kode4food/kombi/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+const config = "# settings\nport = 80\nname = \"x\" # trailing\n"
+
+var classes = highlight.Classes{
+	"Key":                "variable",
+	`"="`:                "operator",
+	"Number":             "number",
+	"String":             "string",
+	highlight.TriviaKind: "comment",
+}
+
+func configParser(t *testing.T) parse.Parser {
+	g, err := grammar.Parse(`
+		Config <- Entry* !.
+		Entry  <- Key "=" Value
+		Key    <- [a-z]+
+		Value  <- Number / String
+		Number <- [0-9]+
+		String <- ["] [^"]* ["]
+	`)
+	assert.Nil(t, err)
+	p, err := g.CompileCST(parse.RegExp(`\s+|#[^\n]*`))
+	assert.Nil(t, err)
+	return p["Config"]
+}
+
+func token(start, end int, class string) highlight.Token {
+	return highlight.Token{
+		Span:  parse.Span{Start: start, End: end},
+		Class: class,
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	as := assert.New(t)
+	toks, err := highlight.Parse(configParser(t), classes, config)
+	as.Nil(err)
+	as.Equal([]highlight.Token{
+		token(0, 10, "comment"),
+		token(11, 15, "variable"),
+		token(16, 17, "operator"),
+		token(18, 20, "number"),
+		token(21, 25, "variable"),
+		token(26, 27, "operator"),
+		token(28, 31, "string"),
+		token(32, 42, "comment"),
+	}, toks)
+
+	delete(classes, highlight.TriviaKind)
+	defer func() {
+		classes[highlight.TriviaKind] = "comment"
+	}()
+	toks, err = highlight.Parse(configParser(t), classes, config)
+	as.Nil(err)
+	as.Equal(6, len(toks))
+	as.Equal(token(11, 15, "variable"), toks[0])
+}
+
+func TestTokenizeErrors(t *testing.T) {
+	as := assert.New(t)
+	toks, err := highlight.Parse(configParser(t), classes, "port = ")
+	as.Nil(toks)
+	as.NotNil(err)
+
+	toks, err = highlight.Parse(parse.String("x"), classes, "x")
+	as.Nil(toks)
+	as.EqualError(err, fmt.Sprintf(highlight.ErrNotNode, "x"))
+}
@@ -0,0 +1,139 @@
+package lsp
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/kode4food/kombi/cst"
+	"github.com/kode4food/kombi/parse"
+)
+
+// document is an open text document, along with the results of its most
+// recent parse. Its Input is retained so that changes can be applied as
+// Edits, allowing a memoizing Parser to reparse it incrementally
+type document struct {
+	uri         string
+	version     int
+	input       parse.Input
+	lines       []int
+	tree        *cst.Node
+	diagnostics []Diagnostic
+}
+
+func newDocument(uri string, version int, text string) *document {
+	d := &document{
+		uri:     uri,
+		version: version,
+	}
+	d.setInput(parse.NewInput(text))
+	return d
+}
+
+func (d *document) text() string {
+	return d.input.String()
+}
+
+func (d *document) apply(c contentChange) {
+	if c.Range == nil {
+		d.setInput(parse.NewInput(c.Text))
+		return
+	}
+	start := d.offset(c.Range.Start)
+	end := d.offset(c.Range.End)
+	if end < start {
+		start, end = end, start
+	}
+	d.setInput(d.input.Edit(parse.Edit{
+		Start: start,
+		End:   end,
+		Text:  c.Text,
+	}))
+}
+
+func (d *document) setInput(i parse.Input) {
+	d.input = i
+	d.lines = []int{0}
+	text := i.String()
+	for idx := 0; idx < len(text); idx++ {
+		if text[idx] == '\n' {
+			d.lines = append(d.lines, idx+1)
+		}
+	}
+}
+
+func (d *document) parse(l *Language) {
+	msg := l.Messages
+	if msg == nil {
+		msg = parse.English
+	}
+	r := parse.Diagnose(l.Parser, d.input)
+	d.tree, _ = r.Result.(*cst.Node)
+	d.diagnostics = make([]Diagnostic, len(r.Diagnostics))
+	for idx, diag := range r.Diagnostics {
+		start := diag.Start
+		if diag.Skipped == "" {
+			start = diag.Offset()
+		}
+		d.diagnostics[idx] = Diagnostic{
+			Range:    d.rangeOf(start, start+len(diag.Skipped)),
+			Severity: SeverityError,
+			Message:  msg.Format(diag.Error),
+		}
+	}
+}
+
+func (d *document) rangeOf(start, end int) Range {
+	return Range{
+		Start: d.position(start),
+		End:   d.position(end),
+	}
+}
+
+func (d *document) line(offset int) int {
+	return sort.Search(len(d.lines), func(i int) bool {
+		return d.lines[i] > offset
+	}) - 1
+}
+
+func (d *document) position(offset int) Position {
+	line := d.line(offset)
+	return Position{
+		Line:      line,
+		Character: utf16Len(d.text()[d.lines[line]:offset]),
+	}
+}
+
+func (d *document) offset(p Position) int {
+	if p.Line < 0 {
+		return 0
+	}
+	text := d.text()
+	if p.Line >= len(d.lines) {
+		return len(text)
+	}
+	res := d.lines[p.Line]
+	for units := 0; units < p.Character && res < len(text); {
+		r, size := utf8.DecodeRuneInString(text[res:])
+		if r == '\n' {
+			break
+		}
+		units += utf16RuneLen(r)
+		res += size
+	}
+	return res
+}
+
+func utf16Len(s string) int {
+	res := 0
+	for _, r := range s {
+		res += utf16RuneLen(r)
+	}
+	return res
+}
+
+func utf16RuneLen(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,131 @@
+package lsp
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/kode4food/kombi/cst"
+	"github.com/kode4food/kombi/highlight"
+	"github.com/kode4food/kombi/parse"
+)
+
+func (s *Server) documentSymbol(params json.RawMessage) (any, error) {
+	d, err := s.document(params)
+	if err != nil {
+		return nil, err
+	}
+	res := []DocumentSymbol{}
+	if d.tree != nil {
+		res = append(res, s.symbols(d, d.tree)...)
+	}
+	return res, nil
+}
+
+func (s *Server) symbols(d *document, n *cst.Node) []DocumentSymbol {
+	var children []DocumentSymbol
+	for _, c := range n.Children() {
+		children = append(children, s.symbols(d, c)...)
+	}
+	sym, ok := s.lang.Symbols[n.Kind]
+	if !ok {
+		return children
+	}
+	name := n
+	if sym.NameKind != "" {
+		if found := findKind(n.Children(), sym.NameKind); found != nil {
+			name = found
+		}
+	}
+	text := strings.TrimSpace(spanText(d, name.Span()))
+	if text == "" {
+		text = n.Kind
+	}
+	return []DocumentSymbol{{
+		Name:           text,
+		Kind:           sym.Kind,
+		Range:          d.rangeOf(n.Span().Start, n.Span().End),
+		SelectionRange: d.rangeOf(name.Span().Start, name.Span().End),
+		Children:       children,
+	}}
+}
+
+func (s *Server) foldingRange(params json.RawMessage) (any, error) {
+	d, err := s.document(params)
+	if err != nil {
+		return nil, err
+	}
+	res := []FoldingRange{}
+	var walk func(n *cst.Node)
+	walk = func(n *cst.Node) {
+		if s.folds[n.Kind] {
+			start := d.line(n.Span().Start)
+			end := d.line(n.Span().End)
+			if end > start {
+				res = append(res, FoldingRange{
+					StartLine: start,
+					EndLine:   end,
+				})
+			}
+		}
+		for _, c := range n.Children() {
+			walk(c)
+		}
+	}
+	if d.tree != nil {
+		walk(d.tree)
+	}
+	return res, nil
+}
+
+func (s *Server) semanticTokens(params json.RawMessage) (any, error) {
+	d, err := s.document(params)
+	if err != nil {
+		return nil, err
+	}
+	res := &SemanticTokens{Data: []int{}}
+	prev := Position{}
+	emit := func(start, end, typ int) {
+		pos := d.position(start)
+		char := pos.Character
+		if pos.Line == prev.Line {
+			char -= prev.Character
+		}
+		res.Data = append(res.Data,
+			pos.Line-prev.Line, char, utf16Len(d.text()[start:end]), typ, 0,
+		)
+		prev = pos
+	}
+	if d.tree == nil {
+		return res, nil
+	}
+	for _, t := range highlight.Tokenize(d.tree, s.lang.Tokens) {
+		typ := s.tokenTypes[t.Class]
+		for start := t.Span.Start; start < t.Span.End; {
+			end := t.Span.End
+			if nl := strings.IndexByte(d.text()[start:end], '\n'); nl >= 0 {
+				end = start + nl
+			}
+			if end > start {
+				emit(start, end, typ)
+			}
+			start = end + 1
+		}
+	}
+	return res, nil
+}
+
+func findKind(nodes []*cst.Node, kind string) *cst.Node {
+	for _, n := range nodes {
+		if n.Kind == kind {
+			return n
+		}
+		if res := findKind(n.Children(), kind); res != nil {
+			return res
+		}
+	}
+	return nil
+}
+
+func spanText(d *document, s parse.Span) string {
+	return d.text()[s.Start:s.End]
+}
@@ -0,0 +1,161 @@
+package lsp
+
+type (
+	// SymbolKind identifies the kind of a DocumentSymbol
+	SymbolKind int
+
+	// Severity identifies the severity of a Diagnostic
+	Severity int
+
+	// Position is a zero-based line and character offset within a
+	// document. Characters are counted in UTF-16 code units
+	Position struct {
+		Line      int `json:"line"`
+		Character int `json:"character"`
+	}
+
+	// Range is the span of a document between two Positions
+	Range struct {
+		Start Position `json:"start"`
+		End   Position `json:"end"`
+	}
+
+	// Diagnostic reports a problem found while parsing a document
+	Diagnostic struct {
+		Range    Range    `json:"range"`
+		Severity Severity `json:"severity"`
+		Source   string   `json:"source,omitempty"`
+		Message  string   `json:"message"`
+	}
+
+	// DocumentSymbol is a named region of a document, such as a
+	// declaration, that may contain other DocumentSymbols
+	DocumentSymbol struct {
+		Name           string           `json:"name"`
+		Kind           SymbolKind       `json:"kind"`
+		Range          Range            `json:"range"`
+		SelectionRange Range            `json:"selectionRange"`
+		Children       []DocumentSymbol `json:"children,omitempty"`
+	}
+
+	// FoldingRange is a range of lines that an editor may collapse
+	FoldingRange struct {
+		StartLine int `json:"startLine"`
+		EndLine   int `json:"endLine"`
+	}
+
+	// SemanticTokens are the relative, encoded semantic tokens of a
+	// document, as described by the protocol specification
+	SemanticTokens struct {
+		Data []int `json:"data"`
+	}
+
+	textDocumentItem struct {
+		URI     string `json:"uri"`
+		Version int    `json:"version"`
+		Text    string `json:"text"`
+	}
+
+	textDocumentID struct {
+		URI     string `json:"uri"`
+		Version int    `json:"version,omitempty"`
+	}
+
+	contentChange struct {
+		Range *Range `json:"range,omitempty"`
+		Text  string `json:"text"`
+	}
+
+	didOpenParams struct {
+		TextDocument textDocumentItem `json:"textDocument"`
+	}
+
+	didChangeParams struct {
+		TextDocument   textDocumentID  `json:"textDocument"`
+		ContentChanges []contentChange `json:"contentChanges"`
+	}
+
+	documentParams struct {
+		TextDocument textDocumentID `json:"textDocument"`
+	}
+
+	logMessageParams struct {
+		Type    int    `json:"type"`
+		Message string `json:"message"`
+	}
+
+	publishDiagnosticsParams struct {
+		URI         string       `json:"uri"`
+		Version     int          `json:"version"`
+		Diagnostics []Diagnostic `json:"diagnostics"`
+	}
+
+	initializeResult struct {
+		Capabilities capabilities `json:"capabilities"`
+		ServerInfo   serverInfo   `json:"serverInfo"`
+	}
+
+	serverInfo struct {
+		Name string `json:"name"`
+	}
+
+	capabilities struct {
+		TextDocumentSync       int                 `json:"textDocumentSync"`
+		DocumentSymbolProvider bool                `json:"documentSymbolProvider"`
+		FoldingRangeProvider   bool                `json:"foldingRangeProvider"`
+		SemanticTokensProvider *semanticTokensOpts `json:"semanticTokensProvider,omitempty"`
+	}
+
+	semanticTokensOpts struct {
+		Legend semanticTokensLegend `json:"legend"`
+		Full   bool                 `json:"full"`
+	}
+
+	semanticTokensLegend struct {
+		TokenTypes     []string `json:"tokenTypes"`
+		TokenModifiers []string `json:"tokenModifiers"`
+	}
+)
+
+// SymbolKinds defined by the protocol specification
+const (
+	SymbolFile SymbolKind = iota + 1
+	SymbolModule
+	SymbolNamespace
+	SymbolPackage
+	SymbolClass
+	SymbolMethod
+	SymbolProperty
+	SymbolField
+	SymbolConstructor
+	SymbolEnum
+	SymbolInterface
+	SymbolFunction
+	SymbolVariable
+	SymbolConstant
+	SymbolString
+	SymbolNumber
+	SymbolBoolean
+	SymbolArray
+	SymbolObject
+	SymbolKey
+	SymbolNull
+	SymbolEnumMember
+	SymbolStruct
+	SymbolEvent
+	SymbolOperator
+	SymbolTypeParameter
+)
+
+// Severities defined by the protocol specification
+const (
+	SeverityError Severity = iota + 1
+	SeverityWarning
+	SeverityInformation
+	SeverityHint
+)
+
+const (
+	syncIncremental = 2
+	messageError    = 1
+)
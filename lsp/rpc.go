@@ -0,0 +1,101 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type (
+	request struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id,omitempty"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params,omitempty"`
+	}
+
+	response struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Result  json.RawMessage `json:"result,omitempty"`
+		Error   *responseError  `json:"error,omitempty"`
+	}
+
+	notification struct {
+		JSONRPC string `json:"jsonrpc"`
+		Method  string `json:"method"`
+		Params  any    `json:"params"`
+	}
+
+	responseError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+// Error messages
+const (
+	ErrMissingLength = "message is missing its Content-Length header"
+	ErrInvalidLength = "invalid Content-Length header: %s"
+)
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidParams  = -32602
+	codeMethodNotFound = -32601
+	codeInvalidRequest = -32600
+	codeInternalError  = -32603
+)
+
+const (
+	jsonRPCVersion = "2.0"
+	lengthHeader   = "content-length"
+)
+
+var null = json.RawMessage("null")
+
+func readMessage(r *bufio.Reader) ([]byte, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || strings.ToLower(strings.TrimSpace(name)) != lengthHeader {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if length, err = strconv.Atoi(value); err != nil || length < 0 {
+			return nil, fmt.Errorf(ErrInvalidLength, value)
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf(ErrMissingLength)
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func writeMessage(w io.Writer, msg any) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
@@ -0,0 +1,296 @@
+// Package lsp serves a minimal Language Server Protocol implementation
+// over a pair of streams, such as stdin and stdout. Documents are parsed
+// with a Parser that produces concrete syntax trees, from which the Server
+// derives diagnostics, document symbols, folding ranges, and semantic tokens
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/kode4food/kombi/highlight"
+	"github.com/kode4food/kombi/parse"
+)
+
+type (
+	// Language describes how a Server interprets its documents. Parser
+	// must produce *cst.Node results, such as those of a Grammar compiled
+	// with CompileCST. Memoized Parsers will reparse incrementally as
+	// documents change. Symbols maps Node kinds to the DocumentSymbols
+	// they declare, Folds lists the Node kinds that can be folded, and
+	// Tokens classifies Node kinds by the semantic token types that
+	// highlight them. Messages renders the text of diagnostics, and
+	// defaults to parse.English
+	Language struct {
+		Name     string
+		Parser   parse.Parser
+		Symbols  map[string]Symbol
+		Folds    []string
+		Tokens   highlight.Classes
+		Messages parse.Formatter
+	}
+
+	// Symbol describes the DocumentSymbol that a Node declares. The name of
+	// the symbol is the text of the first descendant Node of the NameKind,
+	// or the text of the Node itself if NameKind is empty
+	Symbol struct {
+		Kind     SymbolKind
+		NameKind string
+	}
+
+	// Server is a Language Server that responds to the requests of a
+	// single client
+	Server struct {
+		lang       *Language
+		folds      map[string]bool
+		legend     []string
+		tokenTypes map[string]int
+		documents  map[string]*document
+		out        io.Writer
+		shutdown   bool
+	}
+
+	handler func(*Server, json.RawMessage) (any, error)
+
+	notifier func(*Server, json.RawMessage) (*document, error)
+)
+
+// Error messages
+const (
+	ErrUnknownMethod   = "method not found: %s"
+	ErrUnknownDocument = "document is not open: %s"
+	ErrShutdown        = "server is shutting down"
+	ErrNotification    = "invalid %s notification: %s"
+)
+
+var (
+	handlers = map[string]handler{
+		"initialize":                       (*Server).initialize,
+		"shutdown":                         (*Server).shutdownRequest,
+		"textDocument/documentSymbol":      (*Server).documentSymbol,
+		"textDocument/foldingRange":        (*Server).foldingRange,
+		"textDocument/semanticTokens/full": (*Server).semanticTokens,
+	}
+
+	notifiers = map[string]notifier{
+		"textDocument/didOpen":   (*Server).didOpen,
+		"textDocument/didChange": (*Server).didChange,
+		"textDocument/didClose":  (*Server).didClose,
+	}
+
+	errExit = errors.New("exit")
+)
+
+// NewServer returns a new Server for the provided Language
+func NewServer(lang *Language) *Server {
+	s := &Server{
+		lang:       lang,
+		folds:      map[string]bool{},
+		tokenTypes: map[string]int{},
+		documents:  map[string]*document{},
+	}
+	for _, k := range lang.Folds {
+		s.folds[k] = true
+	}
+	for _, t := range lang.Tokens {
+		if _, ok := s.tokenTypes[t]; !ok {
+			s.tokenTypes[t] = 0
+			s.legend = append(s.legend, t)
+		}
+	}
+	sort.Strings(s.legend)
+	for i, t := range s.legend {
+		s.tokenTypes[t] = i
+	}
+	return s
+}
+
+// Serve reads client messages from the provided Reader and writes the
+// Server's messages to the provided Writer, until the client sends an exit
+// notification or the Reader is exhausted. Requests that can't be handled
+// are answered with errors, and notifications that can't be handled are
+// logged to the client, so Serve only returns an error if reading or
+// writing a message fails
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	s.out = w
+	in := bufio.NewReader(r)
+	for {
+		msg, err := readMessage(in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.dispatch(msg); err != nil {
+			if err == errExit {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (s *Server) dispatch(msg []byte) error {
+	var req request
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return s.respondError(null, codeParseError, err.Error())
+	}
+	if req.ID == nil {
+		return s.notify(&req)
+	}
+	if s.shutdown {
+		return s.respondError(req.ID, codeInvalidRequest, ErrShutdown)
+	}
+	h, ok := handlers[req.Method]
+	if !ok {
+		return s.respondError(req.ID, codeMethodNotFound,
+			fmt.Sprintf(ErrUnknownMethod, req.Method),
+		)
+	}
+	res, err := h(s, req.Params)
+	if err != nil {
+		return s.respondError(req.ID, codeInvalidParams, err.Error())
+	}
+	body, err := json.Marshal(res)
+	if err != nil {
+		return s.respondError(req.ID, codeInternalError, err.Error())
+	}
+	return writeMessage(s.out, &response{
+		JSONRPC: jsonRPCVersion,
+		ID:      req.ID,
+		Result:  body,
+	})
+}
+
+func (s *Server) notify(req *request) error {
+	if req.Method == "exit" {
+		return errExit
+	}
+	n, ok := notifiers[req.Method]
+	if !ok {
+		return nil
+	}
+	d, err := n(s, req.Params)
+	if err != nil {
+		return s.logError(fmt.Sprintf(ErrNotification, req.Method, err))
+	}
+	if d != nil {
+		return s.publishDiagnostics(d)
+	}
+	return nil
+}
+
+func (s *Server) respondError(id json.RawMessage, code int, msg string) error {
+	return writeMessage(s.out, &response{
+		JSONRPC: jsonRPCVersion,
+		ID:      id,
+		Error: &responseError{
+			Code:    code,
+			Message: msg,
+		},
+	})
+}
+
+func (s *Server) logError(msg string) error {
+	return writeMessage(s.out, &notification{
+		JSONRPC: jsonRPCVersion,
+		Method:  "window/logMessage",
+		Params: &logMessageParams{
+			Type:    messageError,
+			Message: msg,
+		},
+	})
+}
+
+func (s *Server) publishDiagnostics(d *document) error {
+	return writeMessage(s.out, &notification{
+		JSONRPC: jsonRPCVersion,
+		Method:  "textDocument/publishDiagnostics",
+		Params: &publishDiagnosticsParams{
+			URI:         d.uri,
+			Version:     d.version,
+			Diagnostics: d.diagnostics,
+		},
+	})
+}
+
+func (s *Server) initialize(json.RawMessage) (any, error) {
+	res := &initializeResult{
+		Capabilities: capabilities{
+			TextDocumentSync:       syncIncremental,
+			DocumentSymbolProvider: len(s.lang.Symbols) != 0,
+			FoldingRangeProvider:   len(s.folds) != 0,
+		},
+		ServerInfo: serverInfo{Name: s.lang.Name},
+	}
+	if len(s.legend) != 0 {
+		res.Capabilities.SemanticTokensProvider = &semanticTokensOpts{
+			Legend: semanticTokensLegend{
+				TokenTypes:     s.legend,
+				TokenModifiers: []string{},
+			},
+			Full: true,
+		}
+	}
+	return res, nil
+}
+
+func (s *Server) shutdownRequest(json.RawMessage) (any, error) {
+	s.shutdown = true
+	return nil, nil
+}
+
+func (s *Server) didOpen(params json.RawMessage) (*document, error) {
+	var p didOpenParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	td := p.TextDocument
+	d := newDocument(td.URI, td.Version, td.Text)
+	s.documents[td.URI] = d
+	d.parse(s.lang)
+	return d, nil
+}
+
+func (s *Server) didChange(params json.RawMessage) (*document, error) {
+	var p didChangeParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	d, ok := s.documents[p.TextDocument.URI]
+	if !ok {
+		return nil, nil
+	}
+	d.version = p.TextDocument.Version
+	for _, c := range p.ContentChanges {
+		d.apply(c)
+	}
+	d.parse(s.lang)
+	return d, nil
+}
+
+func (s *Server) didClose(params json.RawMessage) (*document, error) {
+	var p documentParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	delete(s.documents, p.TextDocument.URI)
+	return nil, nil
+}
+
+func (s *Server) document(params json.RawMessage) (*document, error) {
+	var p documentParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	d, ok := s.documents[p.TextDocument.URI]
+	if !ok {
+		return nil, fmt.Errorf(ErrUnknownDocument, p.TextDocument.URI)
+	}
+	return d, nil
+}
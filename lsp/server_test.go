@@ -0,0 +1,404 @@
+package lsp_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kode4food/kombi/cst"
+	"github.com/kode4food/kombi/grammar"
+	"github.com/kode4food/kombi/lsp"
+	"github.com/kode4food/kombi/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	client struct {
+		*assert.Assertions
+		in   io.WriteCloser
+		out  *bufio.Reader
+		id   int
+		done chan error
+	}
+
+	message struct {
+		ID     *int            `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	diagnostics struct {
+		URI         string           `json:"uri"`
+		Version     int              `json:"version"`
+		Diagnostics []lsp.Diagnostic `json:"diagnostics"`
+	}
+)
+
+const uri = "file:///test.cfg"
+
+const doc = "let a = 1;\n" +
+	"let b = {\n" +
+	"  let c = 2;\n" +
+	"};\n"
+
+func testLanguage(t *testing.T) *lsp.Language {
+	g, err := grammar.Parse(`
+		Program <- Decl* !.
+		Decl    <- "let" Name "=" Value ";"
+		Value   <- Number / Block
+		Block   <- "{" Decl* "}"
+		Name    <- [a-z]+
+		Number  <- [0-9]+
+	`)
+	assert.Nil(t, err)
+	p, err := g.CompileCST(parse.RegExp(`\s+`))
+	assert.Nil(t, err)
+	return &lsp.Language{
+		Name:   "cfg",
+		Parser: p["Program"],
+		Symbols: map[string]lsp.Symbol{
+			"Decl": {Kind: lsp.SymbolVariable, NameKind: "Name"},
+		},
+		Folds: []string{"Block"},
+		Tokens: map[string]string{
+			`"let"`:  "keyword",
+			"Name":   "variable",
+			"Number": "number",
+		},
+	}
+}
+
+func newClient(t *testing.T, lang *lsp.Language) *client {
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	c := &client{
+		Assertions: assert.New(t),
+		in:         clientOut,
+		out:        bufio.NewReader(clientIn),
+		done:       make(chan error, 1),
+	}
+	go func() {
+		c.done <- lsp.NewServer(lang).Serve(serverIn, serverOut)
+		_ = serverOut.Close()
+	}()
+	return c
+}
+
+func (c *client) write(msg map[string]any) {
+	msg["jsonrpc"] = "2.0"
+	body, err := json.Marshal(msg)
+	c.Nil(err)
+	_, err = fmt.Fprintf(c.in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	c.Nil(err)
+}
+
+func (c *client) read() *message {
+	length := 0
+	for {
+		line, err := c.out.ReadString('\n')
+		c.Nil(err)
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		value := strings.TrimPrefix(line, "Content-Length: ")
+		length, err = strconv.Atoi(value)
+		c.Nil(err)
+	}
+	body := make([]byte, length)
+	_, err := io.ReadFull(c.out, body)
+	c.Nil(err)
+	var res message
+	c.Nil(json.Unmarshal(body, &res))
+	return &res
+}
+
+func (c *client) notify(method string, params any) {
+	c.write(map[string]any{"method": method, "params": params})
+}
+
+func (c *client) call(method string, params any) *message {
+	c.id++
+	c.write(map[string]any{"id": c.id, "method": method, "params": params})
+	res := c.read()
+	c.Equal(c.id, *res.ID)
+	return res
+}
+
+func (c *client) result(method string, params any, v any) {
+	res := c.call(method, params)
+	c.Nil(res.Error)
+	c.Nil(json.Unmarshal(res.Result, v))
+}
+
+func (c *client) diagnostics() *diagnostics {
+	msg := c.read()
+	c.Equal("textDocument/publishDiagnostics", msg.Method)
+	var res diagnostics
+	c.Nil(json.Unmarshal(msg.Params, &res))
+	return &res
+}
+
+func textDocument() map[string]any {
+	return map[string]any{"textDocument": map[string]any{"uri": uri}}
+}
+
+func rng(sl, sc, el, ec int) lsp.Range {
+	return lsp.Range{
+		Start: lsp.Position{Line: sl, Character: sc},
+		End:   lsp.Position{Line: el, Character: ec},
+	}
+}
+
+func TestServer(t *testing.T) {
+	c := newClient(t, testLanguage(t))
+
+	var init struct {
+		Capabilities struct {
+			TextDocumentSync       int  `json:"textDocumentSync"`
+			DocumentSymbolProvider bool `json:"documentSymbolProvider"`
+			FoldingRangeProvider   bool `json:"foldingRangeProvider"`
+			SemanticTokensProvider struct {
+				Legend struct {
+					TokenTypes []string `json:"tokenTypes"`
+				} `json:"legend"`
+			} `json:"semanticTokensProvider"`
+		} `json:"capabilities"`
+	}
+	c.result("initialize", map[string]any{}, &init)
+	caps := init.Capabilities
+	c.Equal(2, caps.TextDocumentSync)
+	c.True(caps.DocumentSymbolProvider)
+	c.True(caps.FoldingRangeProvider)
+	c.Equal(
+		[]string{"keyword", "number", "variable"},
+		caps.SemanticTokensProvider.Legend.TokenTypes,
+	)
+	c.notify("initialized", map[string]any{})
+
+	c.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{
+			"uri": uri, "languageId": "cfg", "version": 1, "text": doc,
+		},
+	})
+	d := c.diagnostics()
+	c.Equal(uri, d.URI)
+	c.Equal(1, d.Version)
+	c.Empty(d.Diagnostics)
+
+	var symbols []lsp.DocumentSymbol
+	c.result("textDocument/documentSymbol", textDocument(), &symbols)
+	c.Equal([]lsp.DocumentSymbol{
+		{
+			Name:           "a",
+			Kind:           lsp.SymbolVariable,
+			Range:          rng(0, 0, 0, 10),
+			SelectionRange: rng(0, 4, 0, 5),
+		},
+		{
+			Name:           "b",
+			Kind:           lsp.SymbolVariable,
+			Range:          rng(1, 0, 3, 2),
+			SelectionRange: rng(1, 4, 1, 5),
+			Children: []lsp.DocumentSymbol{{
+				Name:           "c",
+				Kind:           lsp.SymbolVariable,
+				Range:          rng(2, 2, 2, 12),
+				SelectionRange: rng(2, 6, 2, 7),
+			}},
+		},
+	}, symbols)
+
+	var folds []lsp.FoldingRange
+	c.result("textDocument/foldingRange", textDocument(), &folds)
+	c.Equal([]lsp.FoldingRange{{StartLine: 1, EndLine: 3}}, folds)
+
+	var tokens lsp.SemanticTokens
+	c.result("textDocument/semanticTokens/full", textDocument(), &tokens)
+	c.Equal([]int{
+		0, 0, 3, 0, 0, 0, 4, 1, 2, 0, 0, 4, 1, 1, 0,
+		1, 0, 3, 0, 0, 0, 4, 1, 2, 0,
+		1, 2, 3, 0, 0, 0, 4, 1, 2, 0, 0, 4, 1, 1, 0,
+	}, tokens.Data)
+
+	change := func(version int, r lsp.Range, text string) {
+		c.notify("textDocument/didChange", map[string]any{
+			"textDocument": map[string]any{"uri": uri, "version": version},
+			"contentChanges": []map[string]any{
+				{"range": r, "text": text},
+			},
+		})
+	}
+
+	change(2, rng(2, 10, 2, 11), "x")
+	d = c.diagnostics()
+	c.Equal(2, d.Version)
+	c.Equal(1, len(d.Diagnostics))
+	c.Equal(lsp.SeverityError, d.Diagnostics[0].Severity)
+	c.Equal(1, d.Diagnostics[0].Range.Start.Line)
+
+	change(3, rng(2, 10, 2, 11), "42")
+	d = c.diagnostics()
+	c.Empty(d.Diagnostics)
+	c.result("textDocument/semanticTokens/full", textDocument(), &tokens)
+	c.Equal([]int{0, 4, 2, 1, 0}, tokens.Data[len(tokens.Data)-5:])
+
+	res := c.call("textDocument/hover", textDocument())
+	c.Equal(-32601, res.Error.Code)
+	c.Equal(fmt.Sprintf(lsp.ErrUnknownMethod, "textDocument/hover"),
+		res.Error.Message,
+	)
+
+	c.notify("textDocument/didClose", textDocument())
+	res = c.call("textDocument/foldingRange", textDocument())
+	c.Equal(fmt.Sprintf(lsp.ErrUnknownDocument, uri), res.Error.Message)
+
+	res = c.call("shutdown", nil)
+	c.Nil(res.Error)
+	c.Equal("null", string(res.Result))
+	res = c.call("textDocument/foldingRange", textDocument())
+	c.Equal(lsp.ErrShutdown, res.Error.Message)
+
+	c.notify("exit", nil)
+	c.Nil(<-c.done)
+}
+
+func TestServerUnicode(t *testing.T) {
+	c := newClient(t, testLanguage(t))
+	c.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{
+			"uri": uri, "version": 1, "text": "let a = 1;\nlet 😀 = 2;",
+		},
+	})
+	d := c.diagnostics()
+	c.Equal(1, len(d.Diagnostics))
+	c.Equal(rng(1, 0, 1, 0), d.Diagnostics[0].Range)
+
+	c.notify("textDocument/didChange", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "version": 2},
+		"contentChanges": []map[string]any{
+			{"range": rng(1, 4, 1, 6), "text": "b"},
+		},
+	})
+	d = c.diagnostics()
+	c.Empty(d.Diagnostics)
+	c.Nil(c.in.Close())
+	c.Nil(<-c.done)
+}
+
+func TestServerMessages(t *testing.T) {
+	lang := testLanguage(t)
+	msgs := parse.Catalog{}
+	for err := range parse.English {
+		msgs[err] = "erreur: %[1]v"
+	}
+	lang.Messages = msgs
+
+	c := newClient(t, lang)
+	c.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{
+			"uri": uri, "version": 1, "text": "let a = 1;\nlet 1 = 2;",
+		},
+	})
+	d := c.diagnostics()
+	c.Equal(1, len(d.Diagnostics))
+	c.True(strings.HasPrefix(d.Diagnostics[0].Message, "erreur: "))
+	c.Nil(c.in.Close())
+	c.Nil(<-c.done)
+}
+
+func TestServerRecovered(t *testing.T) {
+	b := cst.NewBuilder(parse.RegExp(`\s+`))
+	decl := b.Rule("Decl", b.Token("let", parse.String("let")).
+		Concat(b.Token("Name", parse.RegExp("[a-z]+"))).
+		Concat(b.Token("=", parse.String("="))).
+		Concat(b.Token("Number", parse.RegExp("[0-9]+"))),
+	).Recover(parse.String(";"))
+	semi := b.Token(";", parse.String(";"))
+	lang := &lsp.Language{
+		Name:   "cfg",
+		Parser: b.Rule("Program", decl.Concat(semi).ZeroOrMore()),
+	}
+
+	text := "let a = 1;\nlet = 2;\nlet b = 3;\n"
+	c := newClient(t, lang)
+	c.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{
+			"uri": uri, "version": 1, "text": text,
+		},
+	})
+	d := c.diagnostics()
+	c.Equal(1, len(d.Diagnostics))
+	c.Equal(rng(1, 0, 1, 7), d.Diagnostics[0].Range)
+	c.Equal("let = 2", rangeText(text, d.Diagnostics[0].Range))
+	c.Nil(c.in.Close())
+	c.Nil(<-c.done)
+}
+
+func rangeText(text string, r lsp.Range) string {
+	lines := strings.SplitAfter(text, "\n")
+	offset := func(p lsp.Position) int {
+		res := 0
+		for _, l := range lines[:p.Line] {
+			res += len(l)
+		}
+		return res + p.Character
+	}
+	return text[offset(r.Start):offset(r.End)]
+}
+
+func TestServerErrors(t *testing.T) {
+	c := newClient(t, testLanguage(t))
+
+	c.notify("textDocument/didOpen", map[string]any{"textDocument": 5})
+	msg := c.read()
+	c.Equal("window/logMessage", msg.Method)
+	var log struct {
+		Type    int    `json:"type"`
+		Message string `json:"message"`
+	}
+	c.Nil(json.Unmarshal(msg.Params, &log))
+	c.Equal(1, log.Type)
+	c.True(strings.HasPrefix(log.Message,
+		"invalid textDocument/didOpen notification: ",
+	))
+
+	res := c.call("textDocument/documentSymbol", "bad")
+	c.Equal(-32602, res.Error.Code)
+
+	body := "{not json"
+	_, err := fmt.Fprintf(c.in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	c.Nil(err)
+	res = c.read()
+	c.Nil(res.ID)
+	c.Equal(-32700, res.Error.Code)
+
+	c.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "version": 1, "text": doc},
+	})
+	c.Empty(c.diagnostics().Diagnostics)
+
+	res = c.call("shutdown", nil)
+	c.Nil(res.Error)
+	c.notify("exit", nil)
+	c.Nil(<-c.done)
+}
+
+func TestServerFraming(t *testing.T) {
+	as := assert.New(t)
+	s := lsp.NewServer(testLanguage(t))
+	err := s.Serve(strings.NewReader("Content-Type: json\r\n\r\n{}"), io.Discard)
+	as.EqualError(err, lsp.ErrMissingLength)
+
+	err = s.Serve(strings.NewReader("Content-Length: x\r\n\r\n"), io.Discard)
+	as.EqualError(err, fmt.Sprintf(lsp.ErrInvalidLength, "x"))
+}
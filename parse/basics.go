@@ -1,5 +1,7 @@
 package parse
 
+import "errors"
+
 type (
 	// Binder returns a Parser based on the provided result
 	Binder func(any) Parser
@@ -16,9 +18,9 @@ type (
 	eof struct{}
 )
 
-// Error messages
-const (
-	ErrExpectedEndOfFile = "expected end of file"
+// Errors that Parsers fail with
+var (
+	ErrExpectedEndOfFile = errors.New("expected end of file")
 )
 
 // EndOfFile represents the matched EOF result
@@ -37,11 +39,26 @@ func Return(r any) Parser {
 // provided Binder
 func Bind(p Parser, b Binder) Parser {
 	return func(i Input) (*Success, *Failure) {
+		if i.limited() {
+			i.enter()
+			defer i.leave()
+		}
 		s, f := p(i)
-		if f == nil {
-			return b(s.Result)(s.Remaining)
+		if f != nil {
+			return nil, f
+		}
+		bs, bf := i.bind(b, s.Result)(s.Remaining)
+		if bf != nil {
+			return nil, bf.withDiagnostics(s.Diagnostics)
 		}
-		return nil, f
+		if len(s.Diagnostics) == 0 {
+			return bs, nil
+		}
+		return &Success{
+			Result:      bs.Result,
+			Remaining:   bs.Remaining,
+			Diagnostics: concatDiagnostics(s.Diagnostics, bs.Diagnostics),
+		}, nil
 	}
 }
 
@@ -69,24 +86,38 @@ func Fail(msg string, args ...any) Parser {
 	}
 }
 
+// FailWith returns a Parser node that fails with the provided error, so that
+// the error can be tested for with errors.Is and errors.As
+func FailWith(err error) Parser {
+	return func(i Input) (*Success, *Failure) {
+		return i.failWith(err)
+	}
+}
+
 // Satisfy returns a new Parser. This Parser consumes enough of the Input to
-// satisfy the provided Predicate and returns Success on a match
+// satisfy the provided Predicate and returns Success on a match. The result
+// is the matched text
 func Satisfy(p Predicate) Parser {
 	return func(i Input) (*Success, *Failure) {
-		m, err := p(i)
+		i.step()
+		m, err := i.satisfy(p)
 		if err == nil {
+			i.examine(i.offset + m + 1)
 			return i.succeedMatch(m)
 		}
+		i.examine(i.offset + 1)
 		return i.failWith(err)
 	}
 }
 
 // EOF is a Parser that matches the end of the Input
 var EOF = Parser(func(i Input) (*Success, *Failure) {
-	if len(i) == 0 {
+	i.step()
+	i.examine(i.offset + 1)
+	if i.Len() == 0 {
 		return i.succeedWith(EndOfFile)
 	}
-	return i.failExpected(ErrExpectedEndOfFile)
+	return i.failError(ErrExpectedEndOfFile)
 })
 
 // Then returns a new Parser based on the result of the left Parser being
@@ -101,9 +132,18 @@ func Then(l Parser, r Parser) Parser {
 // Parser or the result of the right Parser
 func Or(l Parser, r Parser) Parser {
 	return func(i Input) (*Success, *Failure) {
+		if i.limited() {
+			i.enter()
+			defer i.leave()
+		}
+		u := i.lastUnclosed()
 		if s, f := l(i); f == nil {
 			return s, nil
 		}
-		return r(i)
+		s, f := r(i)
+		if f == nil {
+			i.backtracked(u, s)
+		}
+		return s, f
 	}
 }
@@ -1,7 +1,7 @@
 package parse_test
 
 import (
-	"fmt"
+	"errors"
 	"strconv"
 	"testing"
 
@@ -14,7 +14,7 @@ func TestReturn(t *testing.T) {
 	res := parse.Return("hello")
 	s, f := res.Parse("this is a test")
 	as.SuccessResult(s, f, "hello")
-	as.Equal(parse.Input("this is a test"), s.Remaining)
+	as.Equal("this is a test", s.Remaining.String())
 }
 
 func TestBind(t *testing.T) {
@@ -54,7 +54,7 @@ func TestCapture(t *testing.T) {
 
 	s, f := integer.Parse("nope")
 	as.FailureWrapped(s, f,
-		fmt.Sprintf(parse.ErrExpectedPattern, "[0-9]+"), "nope",
+		parse.ErrExpectedPattern, "[0-9]+", "nope",
 	)
 	as.Equal(0, captured)
 
@@ -72,7 +72,7 @@ func TestAnd(t *testing.T) {
 
 	s, f = hello.Parse("hell no")
 	as.FailureWrapped(s, f,
-		fmt.Sprintf(parse.ErrExpectedString, "hello"),
+		parse.ErrExpectedString, "hello",
 		"hell no",
 	)
 
@@ -112,3 +112,28 @@ func TestMap(t *testing.T) {
 	s, f = intMapper.Parse("hello")
 	as.FailureError(s, f, "couldn't parse int")
 }
+
+func TestFailWith(t *testing.T) {
+	as := NewAssert(t)
+
+	errReserved := errors.New("reserved word")
+	ident := parse.RegExp("[a-z]+").Bind(func(r any) parse.Parser {
+		if r == "goto" {
+			return parse.FailWith(errReserved)
+		}
+		return parse.Return(r)
+	})
+
+	s, f := ident.Parse("label")
+	as.SuccessResult(s, f, "label")
+
+	s, f = ident.Parse("goto")
+	as.FailureError(s, f, "reserved word")
+	as.ErrorIs(f.Error, errReserved)
+	as.Equal(4, f.Input.Offset())
+
+	s, f = parse.String("x").FailWith(errReserved).Parse("x")
+	as.Failure(s, f)
+	as.ErrorIs(f.Error, errReserved)
+	as.Equal(1, f.Input.Offset())
+}
@@ -0,0 +1,176 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type (
+	// Brackets pairs opening delimiters with the closing delimiters that
+	// end them, such as "(" with ")"
+	Brackets map[string]string
+
+	// unclosedDelimiter is the furthest delimiter Failure that a Bracketed
+	// Parser has encountered, along with where its open delimiter was found
+	unclosedDelimiter struct {
+		failure *Failure
+		open    int
+	}
+)
+
+// Errors that Parsers fail with
+var (
+	ErrUnclosedDelimiter   = errors.New("unclosed delimiter")
+	ErrMismatchedDelimiter = errors.New("mismatched delimiter")
+)
+
+// Errors that Brackets panic with
+var (
+	ErrUnknownBracket = errors.New("unknown opening delimiter")
+)
+
+// Error messages
+const (
+	errUnknownBracket = "%w: %s"
+)
+
+// Bracketed returns a new Parser that matches the provided Parser when it is
+// surrounded by the open and close delimiters. The result is that of the
+// provided Parser. If the close delimiter is missing at the end of the
+// Input, the Parser fails with ErrUnclosedDelimiter, reporting where the
+// open delimiter was found. Because it knows of no other delimiters, it
+// can't detect a mismatched one, such as the "]" in "(]". Use the
+// Bracketed method of Brackets that pair every delimiter to detect them
+func Bracketed(open, close string, p Parser) Parser {
+	return Brackets{open: close}.Bracketed(open, p)
+}
+
+// Bracketed returns a new Parser that matches the provided Parser when it is
+// surrounded by the open delimiter and the closing delimiter that it is
+// paired with. The result is that of the provided Parser. If the closing
+// delimiter is missing at the end of the Input, the Parser fails with
+// ErrUnclosedDelimiter. If another of the closing delimiters is found in
+// its place, the Parser fails with ErrMismatchedDelimiter. Both report
+// where the open delimiter was found. If the closing delimiter is missing
+// because a nested Bracketed Parser failed in either way, that nested
+// Failure is reported instead. The provided Parser is expected to consume
+// any whitespace that precedes the closing delimiter
+func (b Brackets) Bracketed(open string, p Parser) Parser {
+	close, ok := b[open]
+	if !ok {
+		panic(fmt.Errorf(errUnknownBracket, ErrUnknownBracket, open))
+	}
+	o := String(open)
+	c := String(close)
+	return func(i Input) (*Success, *Failure) {
+		os, f := o(i)
+		if f != nil {
+			return nil, f
+		}
+		ps, f := p(os.Remaining)
+		if f != nil {
+			return nil, f
+		}
+		cs, f := c(ps.Remaining)
+		if f != nil {
+			f = b.unclosed(i, open, ps.Remaining, f)
+			return nil, f.withDiagnostics(ps.Diagnostics)
+		}
+		return &Success{
+			Result:      ps.Result,
+			Remaining:   cs.Remaining,
+			Diagnostics: concatDiagnostics(ps.Diagnostics, cs.Diagnostics),
+		}, nil
+	}
+}
+
+// ReportUnclosed returns a new Parser that matches the provided Parser. If
+// that Parser fails, but a Bracketed Parser that it performed failed at or
+// beyond the same position because of an unclosed or mismatched delimiter,
+// that Failure is returned instead. Otherwise, an alternative that was
+// attempted after the Bracketed Parser could hide the delimiter's Failure
+func ReportUnclosed(p Parser) Parser {
+	return func(i Input) (*Success, *Failure) {
+		s, f := p(i)
+		if f != nil {
+			return nil, f.Input.reportUnclosed(f)
+		}
+		return s, nil
+	}
+}
+
+func (b Brackets) unclosed(
+	i Input, open string, at Input, f *Failure,
+) *Failure {
+	rest := strings.TrimLeftFunc(at.String(), unicode.IsSpace)
+	if rest == "" {
+		return at.delimiterFailure(i, ErrUnclosedDelimiter, open, i.Position())
+	}
+	if got := b.closer(rest); got != "" {
+		return at.delimiterFailure(i,
+			ErrMismatchedDelimiter, got, b[open], open, i.Position(),
+		)
+	}
+	return at.reportUnclosed(f)
+}
+
+func (i Input) reportUnclosed(f *Failure) *Failure {
+	if i.source == nil {
+		return f
+	}
+	if u := i.unclosed; u != nil && u.failure.offset >= i.offset {
+		return u.failure
+	}
+	return f
+}
+
+// backtracked discards the delimiter Failure that an alternative recorded
+// if it was replaced by another alternative that matched beyond the open
+// delimiter, because the text that the Failure reports on was then matched
+func (i Input) backtracked(prev *unclosedDelimiter, s *Success) {
+	if i.source == nil {
+		return
+	}
+	if u := i.unclosed; u != prev && u.open < s.Remaining.offset {
+		i.unclosed = prev
+	}
+}
+
+func (i Input) lastUnclosed() *unclosedDelimiter {
+	if i.source == nil {
+		return nil
+	}
+	return i.unclosed
+}
+
+func (i Input) delimiterFailure(
+	open Input, err error, args ...any,
+) *Failure {
+	f := &Failure{
+		Error: &Error{
+			Err:  err,
+			Args: args,
+			Got:  i.excerpt(),
+		},
+		Input: i,
+	}
+	if u := i.unclosed; u == nil || u.failure.offset <= i.offset {
+		i.unclosed = &unclosedDelimiter{
+			failure: f,
+			open:    open.offset,
+		}
+	}
+	return f
+}
+
+func (b Brackets) closer(s string) string {
+	var res string
+	for _, c := range b {
+		if len(c) > len(res) && strings.HasPrefix(s, c) {
+			res = c
+		}
+	}
+	return res
+}
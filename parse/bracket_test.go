@@ -0,0 +1,103 @@
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+func bracketed() parse.Parser {
+	b := parse.Brackets{"(": ")", "[": "]", "{{": "}}"}
+	ws := parse.RegExp(`\s*`).Skip()
+	var list parse.Parser
+	item := parse.Any(
+		b.Bracketed("(", deferred(&list)),
+		b.Bracketed("[", deferred(&list)),
+		b.Bracketed("{{", deferred(&list)),
+		parse.RegExp(`[a-z0-9]+`),
+	).Left(ws)
+	list = ws.Then(item.ZeroOrMore())
+	return item.ReportUnclosed()
+}
+
+func TestBracketed(t *testing.T) {
+	as := NewAssert(t)
+	p := bracketed()
+
+	s, f := p.Parse("(a [b c] {{ d }})")
+	as.SuccessResults(s, f, "a", "b", "c", "d")
+
+	s, f = p.Parse("(a\n  (b c)")
+	as.FailureError(s, f, "unclosed '(' opened at 1:1")
+	as.ErrorIs(f.Error, parse.ErrUnclosedDelimiter)
+	as.Equal("2:8", f.Input.Position().String())
+
+	s, f = p.Parse("(a]")
+	as.FailureError(s, f,
+		"mismatched ']', expected ')' to close '(' opened at 1:1",
+	)
+	as.ErrorIs(f.Error, parse.ErrMismatchedDelimiter)
+	as.Equal(2, f.Input.Offset())
+
+	s, f = p.Parse("[a (b c]")
+	as.FailureError(s, f,
+		"mismatched ']', expected ')' to close '(' opened at 1:4",
+	)
+
+	s, f = p.Parse("(a (b (c)\n  [d)")
+	as.FailureError(s, f,
+		"mismatched ')', expected ']' to close '[' opened at 2:3",
+	)
+
+	s, f = p.Parse("(a {{ b)")
+	as.FailureError(s, f,
+		"mismatched ')', expected '}}' to close '{{' opened at 1:4",
+	)
+
+	s, f = p.Parse("(a +)")
+	as.FailureWrapped(s, f, parse.ErrExpectedPattern, "[a-z0-9]+", "(a +)")
+}
+
+func TestBracketedPair(t *testing.T) {
+	as := NewAssert(t)
+
+	p := parse.RegExp(`[0-9]+`).Bracketed("<", ">")
+	s, f := p.Parse("<42>")
+	as.SuccessResult(s, f, "42")
+
+	s, f = p.Parse("<42")
+	as.FailureError(s, f, "unclosed '<' opened at 1:1")
+
+	s, f = p.Parse("<42)")
+	as.FailureWrapped(s, f, parse.ErrExpectedString, ">", ")")
+
+	as.PanicsWithError("unknown opening delimiter: {", func() {
+		parse.Brackets{"(": ")"}.Bracketed("{", p)
+	})
+}
+
+func TestBracketedBacktrack(t *testing.T) {
+	as := NewAssert(t)
+	b := parse.Brackets{"(": ")", "[": "]"}
+	word := parse.RegExp(`[a-z]+`)
+	alt := b.Bracketed("(", word).Or(parse.String("(a]"))
+	p := alt.Then(parse.String("!")).Or(parse.String("x")).ReportUnclosed()
+
+	s, f := p.Parse("(a]!")
+	as.SuccessResult(s, f, "!")
+
+	s, f = p.Parse("(a]?")
+	as.FailureWrapped(s, f, parse.ErrExpectedString, "x", "(a]?")
+
+	s, f = p.Parse("(b]")
+	as.FailureError(s, f,
+		"mismatched ']', expected ')' to close '(' opened at 1:1",
+	)
+
+	p = word.Optional().Then(b.Bracketed("(", word).Optional()).
+		Then(parse.EOF).ReportUnclosed()
+	s, f = p.Parse("a(b]")
+	as.FailureError(s, f,
+		"mismatched ']', expected ')' to close '(' opened at 1:2",
+	)
+}
@@ -1,5 +1,10 @@
 package parse
 
+import (
+	"errors"
+	"fmt"
+)
+
 type (
 	// Combiner takes multiple result values and combines them into one
 	Combiner func(...any) any
@@ -10,8 +15,36 @@ type (
 	// Results represents multiple Results that have been combined. This
 	// is usually the result of the Bind or Then combinators
 	Results []any
+
+	skip struct{}
+)
+
+// Errors that Parsers fail with
+var (
+	ErrExpectedMatches = errors.New("unexpected number of matches")
+	ErrExpectedAtLeast = errors.New("too few matches")
+)
+
+// Errors that Between panics with
+var (
+	ErrInvalidBounds = errors.New("invalid repetition bounds")
 )
 
+// Error messages
+const (
+	errInvalidBounds = "%w: %d to %d"
+)
+
+// Skipped is the result of a Skip Parser. It is dropped when Concatenated or
+// Combined with other results
+var Skipped = &skip{}
+
+// Skip returns a new Parser that matches the provided Parser, but produces a
+// Skipped result that Concat and Combine will drop
+func Skip(p Parser) Parser {
+	return p.Return(Skipped)
+}
+
 // Concat returns a new Parser, the result of which is generated by
 // concatenating the Results of the provided Parsers
 func Concat(l Parser, r Parser) Parser {
@@ -26,10 +59,14 @@ func Concat(l Parser, r Parser) Parser {
 // passing any Combined results to the provided Combiner
 func Combine(p Parser, fn Combiner) Parser {
 	return p.Map(func(r any) any {
-		if res, ok := r.(Results); ok {
-			return fn(res...)
+		switch r := r.(type) {
+		case Results:
+			return fn(r...)
+		case *skip:
+			return fn()
+		default:
+			return fn(r)
 		}
-		return fn(r)
 	})
 }
 
@@ -57,16 +94,76 @@ func Delimited(p Parser, d Delimiter) Parser {
 	return Concat(p, ZeroOrMore(d.Then(p)))
 }
 
+// Count returns a new Parser, the result of which is the Combined set of
+// values matched by the provided Parser being performed exactly n times
+func Count(n int, p Parser) Parser {
+	return Between(n, n, p)
+}
+
+// AtMost returns a new Parser, the result of which is the Combined set of
+// values matched by the provided Parser being performed up to n times
+func AtMost(n int, p Parser) Parser {
+	return Between(0, n, p)
+}
+
+// Between returns a new Parser, the result of which is the Combined set of
+// values matched by the provided Parser being performed at least min times,
+// and up to max times. If fewer than min matches are found, the Parser
+// fails where the provided Parser did, with an error that includes that
+// Parser's error. Between panics if min is negative or exceeds max
+func Between(min, max int, p Parser) Parser {
+	if min < 0 || max < min {
+		panic(fmt.Errorf(errInvalidBounds, ErrInvalidBounds, min, max))
+	}
+	return func(i Input) (*Success, *Failure) {
+		res := Results{}
+		var diags []*Diagnostic
+		n := 0
+		for ; n < max; n++ {
+			s, f := p(i)
+			if f != nil {
+				if n < min {
+					f = tooFewMatches(f, min, max, n)
+					return nil, f.withDiagnostics(diags)
+				}
+				break
+			}
+			res = appendResults(res, s.Result)
+			diags = concatDiagnostics(diags, s.Diagnostics)
+			i = s.Remaining
+		}
+		return &Success{
+			Result:      res,
+			Remaining:   i,
+			Diagnostics: diags,
+		}, nil
+	}
+}
+
+func tooFewMatches(f *Failure, min, max, n int) *Failure {
+	err := ErrExpectedAtLeast
+	if min == max {
+		err = ErrExpectedMatches
+	}
+	_, res := f.Input.failError(err, min, n, f.Error)
+	res.Diagnostics = f.Diagnostics
+	return res
+}
+
 func concatResults(l, r any) Results {
-	var res Results
+	res := Results{}
 	res = appendResults(res, l)
 	res = appendResults(res, r)
 	return res
 }
 
 func appendResults(res Results, r any) Results {
-	if c, ok := r.(Results); ok {
-		return append(res, c...)
+	switch r := r.(type) {
+	case Results:
+		return append(res, r...)
+	case *skip:
+		return res
+	default:
+		return append(res, r)
 	}
-	return append(res, r)
 }
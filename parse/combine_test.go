@@ -44,7 +44,7 @@ func TestOneOrMore(t *testing.T) {
 
 	s, f = many.Parse("blah")
 	as.FailureWrapped(s, f,
-		fmt.Sprintf(parse.ErrExpectedString, "hello"),
+		parse.ErrExpectedString, "hello",
 		"blah",
 	)
 }
@@ -58,7 +58,7 @@ func TestZeroOrMore(t *testing.T) {
 
 	s, f = many.Parse("blah")
 	as.SuccessResults(s, f)
-	as.Equal(parse.Input("blah"), s.Remaining)
+	as.Equal("blah", s.Remaining.String())
 }
 
 func TestDelimited(t *testing.T) {
@@ -77,3 +77,107 @@ func stringResults(r ...any) any {
 	}
 	return buf.String()
 }
+
+func TestCount(t *testing.T) {
+	as := NewAssert(t)
+
+	hex := parse.RegExp("[0-9a-fA-F]").Count(4)
+	s, f := hex.Parse("00fF9")
+	as.SuccessResults(s, f, "0", "0", "f", "F")
+	as.Equal("9", s.Remaining.String())
+
+	s, f = hex.Parse("0fz")
+	as.FailureError(s, f,
+		"expected 4 matches, got 2: "+
+			"expected pattern: [0-9a-fA-F], got z",
+	)
+	as.ErrorIs(f.Error, parse.ErrExpectedMatches)
+	as.Equal("z", f.Input.String())
+
+	s, f = hex.Count(0).Parse("0f")
+	as.SuccessResults(s, f)
+	as.Equal("0f", s.Remaining.String())
+}
+
+func TestBetween(t *testing.T) {
+	as := NewAssert(t)
+
+	octet := parse.RegExp("[0-9]").Between(1, 3)
+	s, f := octet.Parse("1921")
+	as.SuccessResults(s, f, "1", "9", "2")
+	as.Equal("1", s.Remaining.String())
+
+	s, f = octet.Parse("1.")
+	as.SuccessResults(s, f, "1")
+
+	s, f = octet.Parse(".1")
+	as.FailureError(s, f,
+		"expected at least 1 matches, got 0: "+
+			"expected pattern: [0-9], got .1",
+	)
+	as.ErrorIs(f.Error, parse.ErrExpectedAtLeast)
+	as.Equal(".1", f.Input.String())
+
+	pair := parse.String("a").Concat(parse.String("b")).Count(2)
+	s, f = pair.Parse("abac")
+	as.FailureError(s, f,
+		"expected 2 matches, got 1: "+
+			"expected string b, got c",
+	)
+	as.Equal("c", f.Input.String())
+}
+
+func TestBetweenBounds(t *testing.T) {
+	as := NewAssert(t)
+
+	p := parse.String("a")
+	as.PanicsWithError("invalid repetition bounds: -1 to 2", func() {
+		p.Between(-1, 2)
+	})
+	as.PanicsWithError("invalid repetition bounds: 3 to 2", func() {
+		p.Between(3, 2)
+	})
+	as.PanicsWithError("invalid repetition bounds: -1 to -1", func() {
+		p.Count(-1)
+	})
+	as.NotPanics(func() {
+		p.Between(2, 2)
+	})
+}
+
+func TestAtMost(t *testing.T) {
+	as := NewAssert(t)
+
+	upTo := parse.String("ab").AtMost(2)
+	s, f := upTo.Parse("ababab")
+	as.SuccessResults(s, f, "ab", "ab")
+	as.Equal("ab", s.Remaining.String())
+
+	s, f = upTo.Parse("xyz")
+	as.SuccessResults(s, f)
+	as.Equal("xyz", s.Remaining.String())
+}
+
+func TestSkip(t *testing.T) {
+	as := NewAssert(t)
+
+	ws := parse.RegExp(" *").Skip()
+	pair := parse.RegExp("[a-z]+").Concat(ws).
+		Concat(parse.String("=").Skip()).Concat(ws).
+		Concat(parse.RegExp("[0-9]+"))
+	s, f := pair.Parse("x = 42")
+	as.SuccessResults(s, f, "x", "42")
+
+	s, f = ws.Parse("   ")
+	as.SuccessResult(s, f, parse.Skipped)
+
+	count := ws.Combine(func(r ...any) any {
+		return len(r)
+	})
+	s, f = count.Parse("  ")
+	as.SuccessResult(s, f, 0)
+
+	joined := pair.Combine(stringResults)
+	s, f = joined.Parse("y=7")
+	as.SuccessResult(s, f, "y->7->")
+}
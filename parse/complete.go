@@ -0,0 +1,146 @@
+package parse
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+type (
+	// ExpectationKind identifies what an Expectation describes
+	ExpectationKind int
+
+	// Expectation describes something that a Parser would accept at a
+	// position in the Input. Offset is where the accepted text would begin,
+	// which precedes the completion position if it has been partially typed
+	Expectation struct {
+		Kind   ExpectationKind
+		Value  string
+		Offset int
+	}
+
+	collector struct {
+		seen     map[Expectation]bool
+		list     []Expectation
+		suppress int
+		reached  bool
+	}
+)
+
+// ExpectationKinds that Complete may produce
+const (
+	ExpectLiteral ExpectationKind = iota
+	ExpectPattern
+	ExpectLabel
+)
+
+// Errors that Parsers fail with
+var (
+	ErrExpectedLabel = errors.New("expected")
+)
+
+// Label returns a new Parser that matches the provided Parser, but that
+// describes it by name. If the provided Parser fails without consuming any
+// of the Input, the Failure's error reports that the name was expected.
+// When Completing, the name replaces any expectations of the provided
+// Parser
+func Label(name string, p Parser) Parser {
+	return func(i Input) (*Success, *Failure) {
+		c := i.expected
+		if c == nil {
+			s, f := p(i)
+			return s, i.relabel(f, name)
+		}
+		reached := c.reached
+		c.reached = false
+		c.suppress++
+		s, f := p(i)
+		c.suppress--
+		if c.reached || s != nil && s.Remaining.Len() == 0 {
+			i.expect(ExpectLabel, name)
+		}
+		c.reached = c.reached || reached
+		return s, i.relabel(f, name)
+	}
+}
+
+// Complete returns what the provided Parser would accept at the specified
+// offset of the provided text. The text is parsed only up to the offset,
+// while every literal, pattern, and Label that is attempted there is
+// collected. Literals and Labels that are partially matched before the
+// offset are also included, so that they can be completed. Expectations
+// are returned in the order they were first attempted. An offset beyond
+// either end of the text is moved to that end, and one that falls within a
+// character is moved back to where the character begins
+func Complete(p Parser, s string, offset int) []Expectation {
+	switch {
+	case offset < 0:
+		offset = 0
+	case offset > len(s):
+		offset = len(s)
+	}
+	for offset < len(s) && offset > 0 && !utf8.RuneStart(s[offset]) {
+		offset--
+	}
+	i := NewInput(s[:offset])
+	c := &collector{seen: map[Expectation]bool{}}
+	i.expected = c
+	p(i)
+	return c.list
+}
+
+// String returns a description of the ExpectationKind
+func (k ExpectationKind) String() string {
+	switch k {
+	case ExpectLiteral:
+		return "literal"
+	case ExpectPattern:
+		return "pattern"
+	default:
+		return "label"
+	}
+}
+
+func (i Input) relabel(f *Failure, name string) *Failure {
+	if f == nil || f.Input.offset != i.offset {
+		return f
+	}
+	return &Failure{
+		Error:       i.Expected(ErrExpectedLabel, name),
+		Input:       i,
+		Diagnostics: f.Diagnostics,
+	}
+}
+
+func (i Input) completing() bool {
+	return i.source != nil && i.expected != nil
+}
+
+func (i Input) expect(k ExpectationKind, v string) {
+	c := i.expected
+	if c.suppress > 0 {
+		c.reached = true
+		return
+	}
+	e := Expectation{Kind: k, Value: v, Offset: i.offset}
+	if !c.seen[e] {
+		c.seen[e] = true
+		c.list = append(c.list, e)
+	}
+}
+
+func (i Input) expectAtEnd(k ExpectationKind, v string) {
+	if i.completing() && i.Len() == 0 {
+		i.expect(k, v)
+	}
+}
+
+func (c *collector) mark() int {
+	return len(c.list)
+}
+
+func (c *collector) rollback(mark int) {
+	for _, e := range c.list[mark:] {
+		delete(c.seen, e)
+	}
+	c.list = c.list[:mark]
+}
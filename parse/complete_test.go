@@ -0,0 +1,93 @@
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+func TestComplete(t *testing.T) {
+	as := NewAssert(t)
+
+	ws := parse.RegExp(`\s*`).Skip()
+	kw := func(s string) parse.Parser {
+		return ws.Then(parse.String(s))
+	}
+	stmt := kw("select").Then(kw("*")).Then(
+		parse.Any(kw("from"), kw("where"), kw("limit")),
+	)
+
+	as.Equal([]parse.Expectation{
+		{Kind: parse.ExpectLiteral, Value: "from", Offset: 9},
+		{Kind: parse.ExpectLiteral, Value: "where", Offset: 9},
+		{Kind: parse.ExpectLiteral, Value: "limit", Offset: 9},
+	}, stmt.Complete("select * ", 9))
+
+	as.Equal([]parse.Expectation{
+		{Kind: parse.ExpectLiteral, Value: "from", Offset: 9},
+	}, stmt.Complete("select * fr", 11))
+
+	as.Equal([]parse.Expectation{
+		{Kind: parse.ExpectLiteral, Value: "select", Offset: 0},
+	}, stmt.Complete("sel and more", 3))
+
+	as.Equal([]parse.Expectation{
+		{Kind: parse.ExpectLiteral, Value: "select", Offset: 0},
+	}, kw("select").Complete("", 100))
+
+	as.Equal([]parse.Expectation{
+		{Kind: parse.ExpectLiteral, Value: "select", Offset: 0},
+	}, kw("select").Complete("select", -1))
+
+	as.Equal([]parse.Expectation{
+		{Kind: parse.ExpectLiteral, Value: "é", Offset: 1},
+	}, kw("a").Then(parse.String("é")).Complete("aé", 2))
+
+	word := parse.RegExp(`[a-z]+`)
+	as.Equal([]parse.Expectation{
+		{Kind: parse.ExpectPattern, Value: `[a-z]+`, Offset: 2},
+	}, word.Then(parse.String(" ")).Then(word).Complete("a b", 2))
+}
+
+func TestCompleteLabel(t *testing.T) {
+	as := NewAssert(t)
+
+	ident := parse.RegExp(`[a-z]+`).Label("identifier")
+	num := parse.RegExp(`[0-9]+`).Label("number")
+	assign := ident.Then(parse.String("=")).Then(ident.Or(num))
+
+	as.Equal([]parse.Expectation{
+		{Kind: parse.ExpectLabel, Value: "identifier", Offset: 2},
+		{Kind: parse.ExpectLabel, Value: "number", Offset: 2},
+	}, assign.Complete("x=", 2))
+
+	as.Equal([]parse.Expectation{
+		{Kind: parse.ExpectLabel, Value: "identifier", Offset: 2},
+	}, assign.Complete("x=ab", 4))
+
+	s, f := assign.Parse("x=+")
+	as.FailureError(s, f, "expected number, got +")
+	as.ErrorIs(f.Error, parse.ErrExpectedLabel)
+
+	s, f = assign.Parse("x=1")
+	as.SuccessResult(s, f, "1")
+}
+
+func TestCompleteNotFollowedBy(t *testing.T) {
+	as := NewAssert(t)
+
+	kw := parse.String("in").NotBefore(parse.RegExp(`[a-z]`))
+	p := kw.Or(parse.String("int"))
+	as.Equal([]parse.Expectation{
+		{Kind: parse.ExpectLiteral, Value: "in", Offset: 0},
+		{Kind: parse.ExpectLiteral, Value: "int", Offset: 0},
+	}, p.Complete("i", 1))
+	as.Empty(p.Complete("in", 2))
+}
+
+func TestExpectationKind(t *testing.T) {
+	as := NewAssert(t)
+	as.Equal("literal", parse.ExpectLiteral.String())
+	as.Equal("pattern", parse.ExpectPattern.String())
+	as.Equal("label", parse.ExpectLabel.String())
+}
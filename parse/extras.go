@@ -1,5 +1,12 @@
 package parse
 
+import "errors"
+
+// Errors that Parsers fail with
+var (
+	ErrUnexpectedInput = errors.New("unexpected input")
+)
+
 // Any returns a Parser, the result of which is generated by attempting the
 // provided Parsers in succession. The first Parser that returns a Success ends
 // the processing, and its Success instance is returned
@@ -21,3 +28,72 @@ func Optional(p Parser) Parser {
 func DefaultTo(p Parser, r any) Parser {
 	return Or(p, Return(r))
 }
+
+// Left returns a new Parser that matches the left Parser followed by the right
+// Parser. The result is that of the left Parser
+func Left(l Parser, r Parser) Parser {
+	return l.Bind(func(lr any) Parser {
+		return r.Return(lr)
+	})
+}
+
+// Right returns a new Parser that matches the left Parser followed by the
+// right Parser. The result is that of the right Parser
+func Right(l Parser, r Parser) Parser {
+	return Then(l, r)
+}
+
+// Enclosed returns a new Parser that matches the provided Parser when it is
+// surrounded by the open and close Parsers, such as parentheses or quotes.
+// The result is that of the enclosed Parser
+func Enclosed(open Parser, close Parser, p Parser) Parser {
+	return Right(open, Left(p, close))
+}
+
+// Peek returns a new Parser that matches the provided Parser without
+// consuming any of the Input. Its result is that of the provided Parser
+func Peek(p Parser) Parser {
+	return func(i Input) (*Success, *Failure) {
+		s, f := p(i)
+		if f != nil {
+			return nil, f
+		}
+		return i.succeedWith(s.Result)
+	}
+}
+
+// NotFollowedBy returns a new Parser that succeeds only if the provided
+// Parser fails to match. It consumes none of the Input and its result is
+// nil. To match a Parser only if another doesn't follow it, use NotBefore
+func NotFollowedBy(p Parser) Parser {
+	return func(i Input) (*Success, *Failure) {
+		if i.completing() {
+			defer i.expected.rollback(i.expected.mark())
+		}
+		s, f := p(i)
+		if f != nil {
+			return i.succeedWith(nil)
+		}
+		return i.failError(ErrUnexpectedInput, i.Until(s.Remaining))
+	}
+}
+
+// NotBefore returns a new Parser that matches the provided Parser only if
+// the other Parser doesn't match what follows it. Unlike NotFollowedBy, it
+// consumes what the provided Parser matches, and its result is that of the
+// provided Parser
+func NotBefore(p Parser, other Parser) Parser {
+	return p.Bind(func(r any) Parser {
+		return NotFollowedBy(other).Return(r)
+	})
+}
+
+// ManyTill returns a new Parser, the result of which is the Combined set of
+// values matched by the provided Parser, performed zero or more times until
+// the end Parser matches. The end Parser's match is consumed, but its result
+// is discarded
+func ManyTill(p Parser, end Parser) Parser {
+	return ZeroOrMore(NotFollowedBy(end).Then(p)).Bind(func(r any) Parser {
+		return end.Return(r)
+	})
+}
@@ -33,14 +33,110 @@ func TestDefaulted(t *testing.T) {
 	optional := parse.String("hello").Optional()
 	s, f := optional.Parse("hello")
 	as.SuccessResult(s, f, "hello")
-	as.Equal(parse.Input(""), s.Remaining)
+	as.Equal("", s.Remaining.String())
 
 	s, f = optional.Parse("doof")
 	as.SuccessResult(s, f, nil)
-	as.Equal(parse.Input("doof"), s.Remaining)
+	as.Equal("doof", s.Remaining.String())
 
 	defaulted := parse.String("hello").DefaultTo("nope")
 	s, f = defaulted.Parse("doof")
 	as.SuccessResult(s, f, "nope")
-	as.Equal(parse.Input("doof"), s.Remaining)
+	as.Equal("doof", s.Remaining.String())
+}
+
+func TestPeek(t *testing.T) {
+	as := NewAssert(t)
+
+	peek := parse.String("hello").Peek()
+	s, f := peek.Parse("hello there")
+	as.SuccessResult(s, f, "hello")
+	as.Equal("hello there", s.Remaining.String())
+
+	s, f = peek.Parse("goodbye")
+	as.FailureWrapped(s, f,
+		parse.ErrExpectedString, "hello", "goodbye",
+	)
+}
+
+func TestNotFollowedBy(t *testing.T) {
+	as := NewAssert(t)
+
+	notHello := parse.NotFollowedBy(parse.String("hello"))
+	s, f := notHello.Parse("goodbye")
+	as.SuccessResult(s, f, nil)
+	as.Equal("goodbye", s.Remaining.String())
+
+	s, f = notHello.Parse("hello there")
+	as.FailureError(s, f, "unexpected input: hello")
+	as.Equal("hello there", f.Input.String())
+}
+
+func TestNotBefore(t *testing.T) {
+	as := NewAssert(t)
+
+	ident := parse.RegExp("[a-z]+").NotBefore(parse.String("("))
+	s, f := ident.Parse("hello there")
+	as.SuccessResult(s, f, "hello")
+	as.Equal(" there", s.Remaining.String())
+
+	s, f = ident.Parse("hello(there)")
+	as.FailureError(s, f, "unexpected input: (")
+	as.ErrorIs(f.Error, parse.ErrUnexpectedInput)
+	as.Equal("(there)", f.Input.String())
+
+	s, f = parse.NotBefore(parse.String("in"), parse.RegExp("[a-z]")).
+		Parse("inside")
+	as.FailureError(s, f, "unexpected input: s")
+}
+
+func TestManyTill(t *testing.T) {
+	as := NewAssert(t)
+
+	comment := parse.String("/*").Then(
+		parse.RegExp("(?s).").ManyTill(parse.String("*/")),
+	)
+	s, f := comment.Parse("/* a*b */ rest")
+	as.SuccessResults(s, f, " ", "a", "*", "b", " ")
+	as.Equal(" rest", s.Remaining.String())
+
+	s, f = comment.Parse("/**/")
+	as.SuccessResults(s, f)
+
+	s, f = comment.Parse("/* unclosed")
+	as.FailureWrapped(s, f, parse.ErrExpectedString, "*/", "")
+}
+
+func TestLeftRight(t *testing.T) {
+	as := NewAssert(t)
+
+	stmt := parse.RegExp("[a-z]+").Left(parse.String(";"))
+	s, f := stmt.Parse("hello;")
+	as.SuccessResult(s, f, "hello")
+	as.Equal("", s.Remaining.String())
+
+	s, f = stmt.Parse("hello")
+	as.FailureWrapped(s, f, parse.ErrExpectedString, ";", "")
+
+	neg := parse.String("-").Right(parse.RegExp("[0-9]+"))
+	s, f = neg.Parse("-42")
+	as.SuccessResult(s, f, "42")
+}
+
+func TestEnclosed(t *testing.T) {
+	as := NewAssert(t)
+
+	list := parse.RegExp("[a-z]").Delimited(parse.String(", ")).
+		Enclosed(parse.String("["), parse.String("]"))
+	s, f := list.Parse("[a, b]")
+	as.SuccessResults(s, f, "a", "b")
+
+	s, f = list.Parse("[a, b")
+	as.FailureWrapped(s, f, parse.ErrExpectedString, "]", "")
+
+	quoted := parse.Enclosed(
+		parse.String(`"`), parse.String(`"`), parse.RegExp(`[^"]*`),
+	)
+	s, f = quoted.Parse(`"hello there"`)
+	as.SuccessResult(s, f, "hello there")
 }
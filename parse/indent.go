@@ -0,0 +1,192 @@
+package parse
+
+import (
+	"errors"
+	"strings"
+)
+
+// Ordering describes how an indentation must compare to another
+type Ordering int
+
+// Orderings that an IndentGuard can enforce
+const (
+	Less Ordering = iota - 1
+	Equal
+	Greater
+)
+
+// Errors that Parsers fail with
+var (
+	ErrIncorrectIndent = errors.New("incorrect indentation")
+)
+
+// IndentLevel is a Parser that consumes none of the Input, and produces
+// the indentation of its position. Indentation is the number of characters
+// that precede a position on its line
+var IndentLevel = Parser(func(i Input) (*Success, *Failure) {
+	return i.succeedWith(i.indentation())
+})
+
+// IndentGuard returns a new Parser that consumes none of the Input, and
+// succeeds if the indentation of its position compares to n according to
+// the provided Ordering. The result is the indentation
+func IndentGuard(o Ordering, n int) Parser {
+	return func(i Input) (*Success, *Failure) {
+		return i.guardIndent(o, n)
+	}
+}
+
+// Indented returns a new Parser that matches the provided Parser only if
+// it starts at an indentation greater than the reference indentation. The
+// reference indentation is established by the innermost enclosing Block
+func Indented(p Parser) Parser {
+	return func(i Input) (*Success, *Failure) {
+		if _, f := i.guardIndent(Greater, i.indent); f != nil {
+			return nil, f
+		}
+		return p(i)
+	}
+}
+
+// SameIndent returns a new Parser that matches the provided Parser only if
+// it starts at the reference indentation established by the innermost
+// enclosing Block
+func SameIndent(p Parser) Parser {
+	return func(i Input) (*Success, *Failure) {
+		if _, f := i.guardIndent(Equal, i.indent); f != nil {
+			return nil, f
+		}
+		return p(i)
+	}
+}
+
+// Block returns a new Parser, the result of which is the Combined set of
+// values matched by the provided Parser performed one or more times. Every
+// match must begin a line at the indentation of the first, which becomes
+// the reference indentation while the provided Parser is performed. The
+// Block ends at the first line that is indented less, and fails at the
+// first line that is indented more. The provided Parser is expected to
+// consume any whitespace that follows its match, including line breaks
+func Block(p Parser) Parser {
+	return func(i Input) (*Success, *Failure) {
+		col := i.indentation()
+		res := Results{}
+		var diags []*Diagnostic
+		for next := i; ; {
+			s, f := p(next.withIndent(col))
+			if f != nil {
+				return nil, f.withDiagnostics(diags)
+			}
+			res = appendResults(res, s.Result)
+			diags = concatDiagnostics(diags, s.Diagnostics)
+			rem := s.Remaining.withIndent(i.indent)
+			if rem.offset == next.offset || rem.Len() == 0 ||
+				!rem.atLineStart() || rem.indentation() < col {
+				return &Success{
+					Result:      res,
+					Remaining:   rem,
+					Diagnostics: diags,
+				}, nil
+			}
+			if _, f := rem.guardIndent(Equal, col); f != nil {
+				return nil, f.withDiagnostics(diags)
+			}
+			next = rem
+		}
+	}
+}
+
+// LineFold returns a new Parser that matches the provided Parser, which may
+// continue across multiple lines. Every line that the match continues onto
+// must be indented more than the line on which it started
+func LineFold(p Parser) Parser {
+	return func(i Input) (*Success, *Failure) {
+		s, f := p(i)
+		if f != nil {
+			return nil, f
+		}
+		base := i.lineIndentation()
+		text := i.Until(s.Remaining)
+		for idx := strings.IndexByte(text, '\n'); idx >= 0; {
+			idx++
+			for idx < len(text) && isIndentSpace(text[idx]) {
+				idx++
+			}
+			if idx < len(text) && text[idx] != '\n' && text[idx] != '\r' {
+				if _, f := i.Advance(idx).guardIndent(Greater, base); f != nil {
+					return nil, f
+				}
+			}
+			next := strings.IndexByte(text[idx:], '\n')
+			if next < 0 {
+				break
+			}
+			idx += next
+		}
+		return s, nil
+	}
+}
+
+func (i Input) guardIndent(o Ordering, n int) (*Success, *Failure) {
+	got := i.indentation()
+	if compareIndent(got, n) == o {
+		return i.succeedWith(got)
+	}
+	return i.failError(ErrIncorrectIndent, got, o, n)
+}
+
+func (i Input) withIndent(n int) Input {
+	return Input{
+		source: i.source,
+		offset: i.offset,
+		state:  i.state,
+		indent: n,
+	}
+}
+
+func (i Input) indentation() int {
+	return i.Position().Column - 1
+}
+
+func (i Input) lineText() string {
+	if i.source == nil {
+		return ""
+	}
+	return i.text[i.lineStarts()[i.line()]:i.offset]
+}
+
+func (i Input) atLineStart() bool {
+	return strings.TrimLeft(i.lineText(), " \t") == ""
+}
+
+func (i Input) lineIndentation() int {
+	line := i.lineText()
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// String returns a description of the Ordering
+func (o Ordering) String() string {
+	switch o {
+	case Less:
+		return "less than"
+	case Greater:
+		return "more than"
+	default:
+		return "exactly"
+	}
+}
+
+func compareIndent(l, r int) Ordering {
+	switch {
+	case l < r:
+		return Less
+	case l > r:
+		return Greater
+	default:
+		return Equal
+	}
+}
+
+func isIndentSpace(b byte) bool {
+	return b == ' ' || b == '\t'
+}
@@ -0,0 +1,138 @@
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+func yamlLike() parse.Parser {
+	sc := parse.RegExp(`\s*`).Skip()
+	key := parse.RegExp(`[a-z]+`).Left(sc)
+	colon := parse.String(":").Left(sc)
+	scalar := parse.RegExp(`[0-9]+`).Left(sc)
+
+	var entries parse.Parser
+	nested := parse.Parser(func(i parse.Input) (*parse.Success, *parse.Failure) {
+		return entries.Indented()(i)
+	})
+	entry := key.Left(colon).Concat(scalar.Or(nested)).Combine(
+		func(r ...any) any {
+			return map[string]any{r[0].(string): r[1]}
+		},
+	)
+	entries = entry.Block().Combine(func(r ...any) any {
+		res := map[string]any{}
+		for _, e := range r {
+			for k, v := range e.(map[string]any) {
+				res[k] = v
+			}
+		}
+		return res
+	})
+	return entries.Left(parse.EOF)
+}
+
+func TestBlock(t *testing.T) {
+	as := NewAssert(t)
+	p := yamlLike()
+
+	s, f := p.Parse("a: 1\nb:\n  c: 2\n  d:\n    e: 3\nf: 4\n")
+	as.Nil(f)
+	as.Equal(map[string]any{
+		"a": "1",
+		"b": map[string]any{
+			"c": "2",
+			"d": map[string]any{"e": "3"},
+		},
+		"f": "4",
+	}, s.Result)
+
+	s, f = p.Parse("a: 1\n b: 2\n")
+	as.Nil(s)
+	as.EqualError(f.Error,
+		"incorrect indentation (got 1, expected exactly 0)",
+	)
+	as.ErrorIs(f.Error, parse.ErrIncorrectIndent)
+	as.Equal("2:2", f.Input.Position().String())
+
+	s, f = p.Parse("a:\n    b: 1\n  c: 2\n")
+	as.Nil(s)
+	as.EqualError(f.Error,
+		"incorrect indentation (got 2, expected exactly 0)",
+	)
+	as.ErrorIs(f.Error, parse.ErrIncorrectIndent)
+
+	s, f = p.Parse("a:\nb: 1\n")
+	as.Nil(s)
+	as.EqualError(f.Error,
+		"incorrect indentation (got 0, expected more than 0)",
+	)
+	as.ErrorIs(f.Error, parse.ErrIncorrectIndent)
+}
+
+func TestSameIndent(t *testing.T) {
+	as := NewAssert(t)
+	sc := parse.RegExp(`\s*`).Skip()
+	word := parse.RegExp(`[a-z]+`).Left(sc)
+	s, f := word.SameIndent().Parse("a")
+	as.Nil(f)
+	as.Equal("a", s.Result)
+
+	s, f = parse.String(" ").Then(word.SameIndent()).Parse(" a")
+	as.Nil(s)
+	as.EqualError(f.Error,
+		"incorrect indentation (got 1, expected exactly 0)",
+	)
+	as.ErrorIs(f.Error, parse.ErrIncorrectIndent)
+
+	block := parse.String("  ").Then(word.SameIndent().Block())
+	s, f = block.Parse("  a\n  b\n")
+	as.Nil(f)
+	as.Equal(parse.Results{"a", "b"}, s.Result)
+
+	s, f = parse.IndentLevel.Parse("x")
+	as.Nil(f)
+	as.Equal(0, s.Result)
+}
+
+func TestIndentGuard(t *testing.T) {
+	as := NewAssert(t)
+	spaces := parse.RegExp(" *")
+
+	s, f := spaces.Then(parse.IndentGuard(parse.Greater, 1)).Parse("  x")
+	as.Nil(f)
+	as.Equal(2, s.Result)
+	as.Equal("x", s.Remaining.String())
+
+	s, f = spaces.Then(parse.IndentGuard(parse.Equal, 4)).Parse("   x")
+	as.Nil(s)
+	as.EqualError(f.Error,
+		"incorrect indentation (got 3, expected exactly 4)",
+	)
+
+	s, f = spaces.Then(parse.IndentGuard(parse.Less, 2)).Parse("   x")
+	as.Nil(s)
+	as.EqualError(f.Error,
+		"incorrect indentation (got 3, expected less than 2)",
+	)
+	as.ErrorIs(f.Error, parse.ErrIncorrectIndent)
+}
+
+func TestLineFold(t *testing.T) {
+	as := NewAssert(t)
+	words := parse.RegExp(`[a-z]+(\s+[a-z]+)*`).LineFold()
+	p := parse.String("  ").Then(words)
+
+	s, f := p.Parse("  abc\n    def\n\n   ghi")
+	as.Nil(f)
+	as.Equal("abc\n    def\n\n   ghi", s.Result)
+
+	s, f = p.Parse("  abc\n    def\n  ghi")
+	as.Nil(s)
+	as.EqualError(f.Error,
+		"incorrect indentation (got 2, expected more than 2)",
+	)
+	as.ErrorIs(f.Error, parse.ErrIncorrectIndent)
+	as.Equal("3:3", f.Input.Position().String())
+}
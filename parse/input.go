@@ -3,21 +3,92 @@ package parse
 import "fmt"
 
 type (
-	// Input represents a Parser's input
-	Input string
+	// Input represents a Parser's position within the text being parsed.
+	// Because every Input retains its source text, the Inputs of a Success
+	// or Failure can report exactly where they occurred. An Input also
+	// carries the user state and reference indentation that are current at
+	// its position
+	Input struct {
+		*source
+		offset int
+		state  any
+		indent int
+	}
 
-	arg = any
-)
+	// Span identifies a range of the source text by its byte offsets
+	Span struct {
+		Start int
+		End   int
+	}
 
-// Error messages
-const (
-	ErrWrappedExpectation = "%s, got %s"
+	source struct {
+		text       string
+		lines      []int
+		memo       *memoTable
+		examined   int
+		positioned bool
+		expected   *collector
+		limiter    *limiter
+		safe       bool
+		suggest    *suggester
+		unclosed   *unclosedDelimiter
+	}
+
+	arg = any
 )
 
 const (
 	maxExpectedGot = 16
 )
 
+// NewInput returns an Input positioned at the beginning of the provided text
+func NewInput(s string) Input {
+	return Input{
+		source: &source{text: s},
+	}
+}
+
+// String returns the text that remains to be parsed
+func (i Input) String() string {
+	if i.source == nil {
+		return ""
+	}
+	return i.text[i.offset:]
+}
+
+// Len returns the number of bytes that remain to be parsed
+func (i Input) Len() int {
+	return len(i.String())
+}
+
+// Offset returns the byte offset of the Input within its source text
+func (i Input) Offset() int {
+	return i.offset
+}
+
+// Advance returns an Input that is positioned n bytes further along
+func (i Input) Advance(n int) Input {
+	return Input{
+		source: i.source,
+		offset: i.offset + n,
+		state:  i.state,
+		indent: i.indent,
+	}
+}
+
+// Until returns the text between this Input and a later Input
+func (i Input) Until(end Input) string {
+	return i.String()[:end.offset-i.offset]
+}
+
+// SpanTo returns the Span between this Input and a later Input
+func (i Input) SpanTo(end Input) Span {
+	return Span{
+		Start: i.offset,
+		End:   end.offset,
+	}
+}
+
 func (i Input) succeedWith(r any) (*Success, *Failure) {
 	return &Success{
 		Result:    r,
@@ -27,26 +98,37 @@ func (i Input) succeedWith(r any) (*Success, *Failure) {
 
 func (i Input) succeedMatch(idx int) (*Success, *Failure) {
 	return &Success{
-		Result:    i[0:idx],
-		Remaining: i[idx:],
+		Result:    i.String()[0:idx],
+		Remaining: i.Advance(idx),
 	}, nil
 }
 
-func (i Input) errExpected(msg string, args ...arg) error {
-	got := i
+// Expected returns an Error that wraps the provided error, which explains
+// what the Input was expected to match. Any details are appended to the
+// explanation, which is followed by a truncated excerpt of what the Input
+// actually contains
+func (i Input) Expected(err error, details ...arg) error {
+	return &Error{
+		Err:  err,
+		Args: details,
+		Got:  i.excerpt(),
+	}
+}
+
+func (i Input) excerpt() string {
+	got := i.String()
 	if len(got) > maxExpectedGot {
 		got = got[0:maxExpectedGot] + "..."
 	}
-	errMsg := fmt.Sprintf(msg, args...)
-	return fmt.Errorf(ErrWrappedExpectation, errMsg, got)
+	return got
 }
 
 func (i Input) failMessage(msg string, args ...arg) (*Success, *Failure) {
 	return i.failWith(fmt.Errorf(msg, args...))
 }
 
-func (i Input) failExpected(msg string, args ...arg) (*Success, *Failure) {
-	return i.failWith(i.errExpected(msg, args...))
+func (i Input) failError(err error, details ...arg) (*Success, *Failure) {
+	return i.failWith(i.Expected(err, details...))
 }
 
 func (i Input) failWith(err error) (*Success, *Failure) {
@@ -55,3 +137,16 @@ func (i Input) failWith(err error) (*Success, *Failure) {
 		Input: i,
 	}
 }
+
+// Len returns the number of bytes covered by the Span
+func (s Span) Len() int {
+	return s.End - s.Start
+}
+
+// Shift returns a Span that is moved by the provided number of bytes
+func (s Span) Shift(delta int) Span {
+	return Span{
+		Start: s.Start + delta,
+		End:   s.End + delta,
+	}
+}
@@ -0,0 +1,40 @@
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+func TestInput(t *testing.T) {
+	as := NewAssert(t)
+
+	i := parse.NewInput("hello there")
+	as.Equal(0, i.Offset())
+	as.Equal(11, i.Len())
+
+	there := i.Advance(6)
+	as.Equal(6, there.Offset())
+	as.Equal("there", there.String())
+	as.Equal("hello ", i.Until(there))
+	as.Equal(parse.Span{Start: 0, End: 6}, i.SpanTo(there))
+	as.Equal(6, i.SpanTo(there).Len())
+	as.Equal(parse.Span{Start: 3, End: 9}, i.SpanTo(there).Shift(3))
+
+	var empty parse.Input
+	as.Equal("", empty.String())
+	as.Equal(0, empty.Len())
+}
+
+func TestSuccessPosition(t *testing.T) {
+	as := NewAssert(t)
+
+	p := parse.String("hello").Then(parse.String(" "))
+	s, f := p.Parse("hello there")
+	as.Success(s, f)
+	as.Equal(6, s.Remaining.Offset())
+
+	s, f = p.Then(parse.String("you")).Parse("hello there")
+	as.Failure(s, f)
+	as.Equal(6, f.Input.Offset())
+}
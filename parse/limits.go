@@ -0,0 +1,176 @@
+package parse
+
+import (
+	"context"
+	"errors"
+)
+
+type (
+	// Limits bound the resources that ParseContext allows a Parser to
+	// consume. A zero value for any Limit leaves it unbounded
+	Limits struct {
+		// MaxInputBytes is the largest Input that will be parsed at all
+		MaxInputBytes int
+
+		// MaxDepth bounds how deeply Parsers can be nested while they are
+		// performed. Repetition nests as well as recursion does
+		MaxDepth int
+
+		// MaxSteps bounds the number of Parsers that can be performed,
+		// including those that are performed again when backtracking
+		MaxSteps int
+
+		// MaxMemoEntries bounds the number of outcomes that Memo Parsers
+		// can remember for the Input's source text
+		MaxMemoEntries int
+	}
+
+	// Limit identifies one of the bounds described by Limits
+	Limit int
+
+	// LimitError is the error of the Failure that ParseContext returns when
+	// a Parser exceeds one of its Limits
+	LimitError struct {
+		Limit Limit
+		Max   int
+	}
+
+	limiter struct {
+		Limits
+		ctx   context.Context
+		steps int
+		depth int
+	}
+
+	abort struct {
+		*Failure
+	}
+)
+
+// Limits that can be exceeded
+const (
+	InputBytesLimit Limit = iota
+	DepthLimit
+	StepsLimit
+	MemoEntriesLimit
+)
+
+// Errors that Parsers fail with
+var (
+	ErrLimitExceeded = errors.New("parse limit exceeded")
+)
+
+// ParseContext uses the provided Parser to match the Input, subject to the
+// provided Limits and to the cancellation of the provided Context. If either
+// brings the parse to a halt, it is abandoned at once, and the returned
+// Failure's error is a *LimitError or the Context's error respectively
+func ParseContext(
+	ctx context.Context, p Parser, i Input, l Limits,
+) (s *Success, f *Failure) {
+	if l.MaxInputBytes > 0 && i.Len() > l.MaxInputBytes {
+		return i.failWith(&LimitError{
+			Limit: InputBytesLimit,
+			Max:   l.MaxInputBytes,
+		})
+	}
+	if err := ctx.Err(); err != nil {
+		return i.failWith(err)
+	}
+	if i.source == nil {
+		i = NewInput("")
+	}
+	prev := i.limiter
+	i.limiter = &limiter{Limits: l, ctx: ctx}
+	defer func() {
+		i.limiter = prev
+		if rec := recover(); rec != nil {
+			a, ok := rec.(*abort)
+			if !ok {
+				panic(rec)
+			}
+			s, f = nil, a.Failure
+		}
+	}()
+	return p(i)
+}
+
+// String returns a description of the Limit
+func (l Limit) String() string {
+	switch l {
+	case InputBytesLimit:
+		return "input bytes"
+	case DepthLimit:
+		return "depth"
+	case StepsLimit:
+		return "steps"
+	default:
+		return "memo entries"
+	}
+}
+
+// Error returns a description of the exceeded Limit
+func (e *LimitError) Error() string {
+	return English.Format(e)
+}
+
+// Unwrap returns ErrLimitExceeded
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+func (i Input) limited() bool {
+	return i.source != nil && i.limiter != nil
+}
+
+func (i Input) step() {
+	if !i.limited() {
+		return
+	}
+	l := i.limiter
+	l.steps++
+	if l.MaxSteps > 0 && l.steps > l.MaxSteps {
+		i.exceeded(StepsLimit, l.MaxSteps)
+	}
+	select {
+	case <-l.ctx.Done():
+		i.abort(l.ctx.Err())
+	default:
+	}
+}
+
+func (i Input) enter() {
+	if !i.limited() {
+		return
+	}
+	i.step()
+	l := i.limiter
+	l.depth++
+	if l.MaxDepth > 0 && l.depth > l.MaxDepth {
+		i.exceeded(DepthLimit, l.MaxDepth)
+	}
+}
+
+func (i Input) leave() {
+	if i.limited() {
+		i.limiter.depth--
+	}
+}
+
+func (i Input) remember() {
+	if !i.limited() {
+		return
+	}
+	max := i.limiter.MaxMemoEntries
+	if max > 0 && i.memo.len() >= max {
+		i.exceeded(MemoEntriesLimit, max)
+	}
+}
+
+func (i Input) exceeded(l Limit, max int) {
+	i.abort(&LimitError{Limit: l, Max: max})
+}
+
+func (i Input) abort(err error) {
+	_, f := i.failWith(err)
+	panic(&abort{Failure: f})
+}
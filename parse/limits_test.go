@@ -0,0 +1,152 @@
+package parse_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+func deferred(p *parse.Parser) parse.Parser {
+	return func(i parse.Input) (*parse.Success, *parse.Failure) {
+		return (*p)(i)
+	}
+}
+
+func nested() parse.Parser {
+	var expr parse.Parser
+	expr = parse.String("x").Or(
+		deferred(&expr).Enclosed(parse.String("("), parse.String(")")),
+	)
+	return expr
+}
+
+func TestParseContext(t *testing.T) {
+	as := NewAssert(t)
+	ctx := context.Background()
+
+	s, f := nested().ParseContext(ctx, "((x))", parse.Limits{
+		MaxInputBytes: 5,
+		MaxDepth:      100,
+		MaxSteps:      100,
+	})
+	as.SuccessResult(s, f, "x")
+
+	s, f = nested().ParseContext(ctx, "((x))", parse.Limits{
+		MaxInputBytes: 4,
+	})
+	as.Failure(s, f)
+	as.Equal(&parse.LimitError{
+		Limit: parse.InputBytesLimit,
+		Max:   4,
+	}, f.Error)
+	as.EqualError(f.Error, "parse limit exceeded: input bytes (max 4)")
+	as.ErrorIs(f.Error, parse.ErrLimitExceeded)
+	as.Equal(0, f.Input.Offset())
+}
+
+func TestParseContextDepth(t *testing.T) {
+	as := NewAssert(t)
+
+	src := strings.Repeat("(", 10000) + "x" + strings.Repeat(")", 10000)
+	s, f := nested().ParseContext(context.Background(), src, parse.Limits{
+		MaxDepth: 1000,
+	})
+	as.Failure(s, f)
+	var le *parse.LimitError
+	as.True(errors.As(f.Error, &le))
+	as.Equal(parse.DepthLimit, le.Limit)
+	as.Greater(f.Input.Offset(), 0)
+	as.Less(f.Input.Offset(), 1000)
+}
+
+func TestParseContextSteps(t *testing.T) {
+	as := NewAssert(t)
+
+	var p parse.Parser
+	a := parse.String("a")
+	p = parse.Any(
+		a.Then(deferred(&p)).Then(parse.String("b")),
+		a.Then(deferred(&p)).Then(parse.String("c")),
+		a,
+	)
+	src := strings.Repeat("a", 40) + "d"
+	s, f := p.ParseContext(context.Background(), src, parse.Limits{
+		MaxSteps: 10000,
+	})
+	as.Failure(s, f)
+	as.Equal(&parse.LimitError{
+		Limit: parse.StepsLimit,
+		Max:   10000,
+	}, f.Error)
+}
+
+func TestParseContextMemo(t *testing.T) {
+	as := NewAssert(t)
+
+	p := parse.String("a").Memo().ZeroOrMore()
+	s, f := p.ParseContext(context.Background(), "aaaa", parse.Limits{
+		MaxMemoEntries: 5,
+	})
+	as.Success(s, f)
+
+	s, f = p.ParseContext(context.Background(), "aaaaa", parse.Limits{
+		MaxMemoEntries: 5,
+	})
+	as.Failure(s, f)
+	as.Equal(&parse.LimitError{
+		Limit: parse.MemoEntriesLimit,
+		Max:   5,
+	}, f.Error)
+	as.Equal(5, f.Input.Offset())
+}
+
+func TestParseContextCancel(t *testing.T) {
+	as := NewAssert(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s, f := nested().ParseContext(ctx, "x", parse.Limits{})
+	as.Failure(s, f)
+	as.True(errors.Is(f.Error, context.Canceled))
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	stop := parse.Return(nil).Map(func(r any) any {
+		cancel()
+		return r
+	})
+	p := stop.Then(parse.String("x").ZeroOrMore())
+	s, f = p.ParseContext(ctx, "xxx", parse.Limits{})
+	as.Failure(s, f)
+	as.True(errors.Is(f.Error, context.Canceled))
+	as.Equal(0, f.Input.Offset())
+}
+
+func TestLimitString(t *testing.T) {
+	as := NewAssert(t)
+	as.Equal("input bytes", parse.InputBytesLimit.String())
+	as.Equal("depth", parse.DepthLimit.String())
+	as.Equal("steps", parse.StepsLimit.String())
+	as.Equal("memo entries", parse.MemoEntriesLimit.String())
+}
+
+func BenchmarkParse(b *testing.B) {
+	p := nested()
+	src := strings.Repeat("(", 100) + "x" + strings.Repeat(")", 100)
+	for n := 0; n < b.N; n++ {
+		p.Parse(src)
+	}
+}
+
+func BenchmarkParseContext(b *testing.B) {
+	p := nested()
+	src := strings.Repeat("(", 100) + "x" + strings.Repeat(")", 100)
+	ctx := context.Background()
+	l := parse.Limits{MaxDepth: 1000, MaxSteps: 100000}
+	for n := 0; n < b.N; n++ {
+		p.ParseContext(ctx, src, l)
+	}
+}
@@ -0,0 +1,372 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type (
+	// Edit describes a change to source text, where the bytes between
+	// Start and End are replaced by Text
+	Edit struct {
+		Start int
+		End   int
+		Text  string
+	}
+
+	// Relocatable is implemented by results that record source offsets.
+	// When a memoized result is reused at a new position after an Edit, it
+	// is Relocated by the distance that the Edit shifted it
+	Relocatable interface {
+		Relocate(delta int) any
+	}
+
+	memoID struct {
+		_ byte
+	}
+
+	memoKey struct {
+		id         *memoID
+		offset     int
+		state      any
+		indent     int
+		suggesting bool
+	}
+
+	memoEntry struct {
+		success    *Success
+		failure    *Failure
+		examined   int
+		positioned bool
+		attempted  *suggester
+	}
+
+	// memoTable holds the outcomes memoized for one version of the source
+	// text. A table that is produced by an Edit doesn't copy the outcomes
+	// of its parent. Instead, those that the Edit left intact are found in
+	// the parent, shifted, and promoted into the table only once they're
+	// used again. Outcomes that depend on the Position of the text that
+	// they examined are only found in the parent if the Edit left that
+	// Position intact, meaning that it changed no line breaks and that the
+	// outcome begins beyond the end of the Edit's last line
+	memoTable struct {
+		entries map[memoKey]*memoEntry
+		parent  *memoTable
+		edit    Edit
+		delta   int
+		depth   int
+		relined bool
+		lineEnd int
+	}
+)
+
+// Errors that Edit panics with
+var (
+	ErrInvalidEdit = errors.New("invalid edit")
+)
+
+// Error messages
+const (
+	errInvalidEdit = "%w of %d bytes: %d to %d"
+)
+
+// maxMemoDepth bounds the number of Edits that memoized outcomes are
+// carried across without being used again
+const maxMemoDepth = 16
+
+// Memo returns a new Parser that remembers the outcome of the provided
+// Parser at every offset of an Input's source text, so that it is performed
+// at most once per offset, user state, and reference indentation. Memoized
+// outcomes also survive an Input's Edit, as long as the Edit doesn't touch
+// any of the text examined to produce them, including the text that their
+// errors quote, nor move them to another line or column if they depend on
+// their Position. Outcomes are only memoized if the user state is
+// comparable, and never while Completing
+func Memo(p Parser) Parser {
+	id := new(memoID)
+	return func(i Input) (*Success, *Failure) {
+		if !i.memoizing() {
+			return p(i)
+		}
+		key := memoKey{
+			id:         id,
+			offset:     i.offset,
+			state:      i.state,
+			indent:     i.indent,
+			suggesting: i.suggest != nil,
+		}
+		if e, ok := i.recall(key); ok {
+			i.replay(e)
+			return e.success, e.failure
+		}
+		i.remember()
+		if i.memo == nil {
+			i.memo = &memoTable{}
+		}
+		prev, positioned := i.examined, i.positioned
+		i.examined, i.positioned = i.offset, false
+		e := &memoEntry{}
+		if key.suggesting {
+			e.success, e.failure, e.attempted = i.suggesting(p)
+		} else {
+			e.success, e.failure = p(i)
+		}
+		e.examined = i.examined
+		e.positioned = i.positioned
+		e.excerpted()
+		i.memo.store(key, e)
+		i.examined, i.positioned = prev, positioned
+		i.replay(e)
+		return e.success, e.failure
+	}
+}
+
+// Edit returns an Input positioned at the beginning of the source text
+// with the Edit applied. Memoized outcomes that examined none of the edited
+// text are carried over to the new Input, shifted if they follow the Edit,
+// so that parsing it again only repeats the work that the Edit affected.
+// Outcomes are carried over lazily, so an Edit takes constant time, and an
+// outcome is only shifted when it's used again. Outcomes that go unused
+// across many Edits are eventually discarded. The new Input carries no
+// user state. An Input without source text is edited as if it were empty
+func (i Input) Edit(e Edit) Input {
+	if i.source == nil {
+		i = NewInput("")
+	}
+	text := i.text
+	if e.Start < 0 || e.End < e.Start || e.End > len(text) {
+		panic(fmt.Errorf(
+			errInvalidEdit, ErrInvalidEdit, len(text), e.Start, e.End,
+		))
+	}
+	res := NewInput(text[:e.Start] + e.Text + text[e.End:])
+	if i.memo != nil {
+		res.memo = i.memo.derive(e, text, res.text)
+	}
+	return res
+}
+
+func (i Input) recall(k memoKey) (*memoEntry, bool) {
+	t := i.memo
+	if t == nil {
+		return nil, false
+	}
+	if e, ok := t.entries[k]; ok {
+		return e, true
+	}
+	m, delta, ok := t.inherit(k)
+	if !ok {
+		return nil, false
+	}
+	e := m.rebase(i, delta)
+	t.store(k, e)
+	return e, true
+}
+
+func (i Input) memoizing() bool {
+	return i.source != nil && i.expected == nil && comparableState(i.state)
+}
+
+// replay applies the examined text and attempted literals of a memoized
+// outcome to the Input's source, as though the outcome had been produced
+// again
+func (i Input) replay(e *memoEntry) {
+	i.examine(e.examined)
+	if e.positioned {
+		i.positioned = true
+	}
+	if e.attempted != nil && i.suggest != nil {
+		i.suggest.merge(e.attempted)
+	}
+}
+
+func (i Input) examine(end int) {
+	if i.source != nil && end > i.examined {
+		i.examined = end
+	}
+}
+
+func (i Input) tracking() bool {
+	return i.source != nil && i.memo != nil
+}
+
+func (t *memoTable) len() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.entries)
+}
+
+func (t *memoTable) store(k memoKey, e *memoEntry) {
+	if t.entries == nil {
+		t.entries = map[memoKey]*memoEntry{}
+	}
+	t.entries[k] = e
+}
+
+// derive returns an empty table for the text that results from applying
+// the Edit, whose parent is this table
+func (t *memoTable) derive(e Edit, from, to string) *memoTable {
+	p := t.trim(maxMemoDepth - 1)
+	end := e.Start + len(e.Text)
+	lineEnd := len(to)
+	if idx := strings.IndexByte(to[end:], '\n'); idx >= 0 {
+		lineEnd = end + idx
+	}
+	return &memoTable{
+		parent: p,
+		edit:   e,
+		delta:  len(e.Text) - (e.End - e.Start),
+		depth:  p.depth + 1,
+		relined: strings.Count(from[e.Start:e.End], "\n") !=
+			strings.Count(e.Text, "\n"),
+		lineEnd: lineEnd,
+	}
+}
+
+// trim returns a table that shares the entries of this one, but that has
+// no more than the provided number of ancestors
+func (t *memoTable) trim(max int) *memoTable {
+	if t.depth <= max {
+		return t
+	}
+	res := *t
+	if max == 0 {
+		res.parent = nil
+		res.depth = 0
+		return &res
+	}
+	res.parent = t.parent.trim(max - 1)
+	res.depth = res.parent.depth + 1
+	return &res
+}
+
+// find returns the entry memoized for the key, along with the distance
+// that its offsets must be shifted in order to be used with this table
+func (t *memoTable) find(k memoKey) (*memoEntry, int, bool) {
+	if e, ok := t.entries[k]; ok {
+		return e, 0, true
+	}
+	return t.inherit(k)
+}
+
+// inherit finds the entry for the key in the table's parent, but only if
+// the Edit that separates them left the entry intact
+func (t *memoTable) inherit(k memoKey) (*memoEntry, int, bool) {
+	p := t.parent
+	if p == nil {
+		return nil, 0, false
+	}
+	e := t.edit
+	if k.offset <= e.Start {
+		m, delta, ok := p.find(k)
+		if ok && m.examined+delta <= e.Start {
+			return m, delta, true
+		}
+	}
+	pk := k
+	pk.offset -= t.delta
+	if pk.offset >= e.End {
+		m, delta, ok := p.find(pk)
+		if ok && (!m.positioned || !t.relined && k.offset > t.lineEnd) {
+			return m, delta + t.delta, true
+		}
+	}
+	return nil, 0, false
+}
+
+// excerpted extends the text that the entry examined to include what the
+// errors of its Failures quote from the text, so that the entry isn't
+// reused after an Edit changes what they would quote
+func (m *memoEntry) excerpted() {
+	if f := m.failure; f != nil {
+		m.excerpt(f)
+		m.excerptDiagnostics(f.Diagnostics)
+		return
+	}
+	m.excerptDiagnostics(m.success.Diagnostics)
+}
+
+func (m *memoEntry) excerptDiagnostics(d []*Diagnostic) {
+	for _, d := range d {
+		m.excerpt(d.Failure)
+	}
+}
+
+func (m *memoEntry) excerpt(f *Failure) {
+	if end := f.offset + maxExpectedGot + 1; end > m.examined {
+		m.examined = end
+	}
+}
+
+func (m *memoEntry) rebase(i Input, delta int) *memoEntry {
+	res := &memoEntry{
+		examined:   m.examined + delta,
+		positioned: m.positioned,
+	}
+	if m.attempted != nil {
+		res.attempted = m.attempted.rebase(delta)
+	}
+	if f := m.failure; f != nil {
+		res.failure = f.rebase(i, delta)
+		return res
+	}
+	s := m.success
+	res.success = &Success{
+		Result:      relocate(s.Result, delta),
+		Remaining:   s.Remaining.rebase(i, delta),
+		Diagnostics: rebaseDiagnostics(s.Diagnostics, i, delta),
+	}
+	return res
+}
+
+func (f *Failure) rebase(i Input, delta int) *Failure {
+	return &Failure{
+		Error:       f.Error,
+		Input:       f.Input.rebase(i, delta),
+		Diagnostics: rebaseDiagnostics(f.Diagnostics, i, delta),
+	}
+}
+
+func rebaseDiagnostics(d []*Diagnostic, i Input, delta int) []*Diagnostic {
+	if len(d) == 0 {
+		return nil
+	}
+	res := make([]*Diagnostic, len(d))
+	for idx, d := range d {
+		res[idx] = &Diagnostic{
+			Failure: d.Failure.rebase(i, delta),
+			Skipped: d.Skipped,
+			Start:   d.Start + delta,
+		}
+	}
+	return res
+}
+
+func (i Input) rebase(to Input, delta int) Input {
+	return Input{
+		source: to.source,
+		offset: i.offset + delta,
+		state:  i.state,
+		indent: i.indent,
+	}
+}
+
+func relocate(r any, delta int) any {
+	if delta == 0 {
+		return r
+	}
+	switch r := r.(type) {
+	case Results:
+		res := make(Results, len(r))
+		for i, e := range r {
+			res[i] = relocate(e, delta)
+		}
+		return res
+	case Relocatable:
+		return r.Relocate(delta)
+	default:
+		return r
+	}
+}
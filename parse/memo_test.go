@@ -0,0 +1,265 @@
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+type assignment struct {
+	name  string
+	value string
+	pos   int
+}
+
+var relocations int
+
+func (a *assignment) Relocate(delta int) any {
+	relocations++
+	res := *a
+	res.pos += delta
+	return &res
+}
+
+func assignments(calls *int) parse.Parser {
+	ws := parse.RegExp(" *").Skip()
+	stmt := func(i parse.Input) (*parse.Success, *parse.Failure) {
+		*calls++
+		return parse.String("let").Skip().Concat(ws).
+			Concat(parse.RegExp("[a-z]+")).Concat(ws).
+			Concat(parse.String("=").Skip()).Concat(ws).
+			Concat(parse.RegExp("[0-9]+")).Concat(ws).
+			Concat(parse.String(";").Skip()).Concat(ws).
+			Combine(func(r ...any) any {
+				return &assignment{
+					name:  r[0].(string),
+					value: r[1].(string),
+					pos:   i.Offset(),
+				}
+			})(i)
+	}
+	return parse.Parser(stmt).Memo().ZeroOrMore().Left(parse.EOF)
+}
+
+func TestMemo(t *testing.T) {
+	as := NewAssert(t)
+
+	calls := 0
+	p := parse.Parser(func(i parse.Input) (*parse.Success, *parse.Failure) {
+		calls++
+		return parse.String("a")(i)
+	}).Memo()
+	twice := p.Concat(parse.String("x")).Or(p.Concat(parse.String("y")))
+
+	s, f := twice.Parse("ay")
+	as.Nil(f)
+	as.Equal(parse.Results{"a", "y"}, s.Result)
+	as.Equal(1, calls)
+
+	calls = 0
+	s, f = parse.Peek(p).Then(p).Parse("a")
+	as.Nil(f)
+	as.Equal("a", s.Result)
+	as.Equal(1, calls)
+}
+
+func TestEdit(t *testing.T) {
+	as := NewAssert(t)
+
+	calls := 0
+	p := assignments(&calls)
+	i := parse.NewInput("let a = 1; let b = 2; let c = 3;")
+	s, f := p(i)
+	as.Nil(f)
+	as.Equal(4, calls)
+
+	calls = 0
+	i = i.Edit(parse.Edit{Start: 19, End: 20, Text: "22"})
+	as.Equal("let a = 1; let b = 22; let c = 3;", i.String())
+	s, f = p(i)
+	as.Nil(f)
+	as.Equal(1, calls)
+
+	res := s.Result.(parse.Results)
+	as.Equal(3, len(res))
+	as.Equal(&assignment{name: "a", value: "1", pos: 0}, res[0])
+	as.Equal(&assignment{name: "b", value: "22", pos: 11}, res[1])
+	as.Equal(&assignment{name: "c", value: "3", pos: 23}, res[2])
+	as.Equal(0, s.Remaining.Len())
+	as.Equal(33, s.Remaining.Offset())
+
+	calls = 0
+	i = i.Edit(parse.Edit{Start: 0, End: 11})
+	s, f = p(i)
+	as.Nil(f)
+	as.Equal(0, calls)
+	res = s.Result.(parse.Results)
+	as.Equal(&assignment{name: "b", value: "22", pos: 0}, res[0])
+	as.Equal(&assignment{name: "c", value: "3", pos: 12}, res[1])
+}
+
+func TestEditLazy(t *testing.T) {
+	as := NewAssert(t)
+
+	calls := 0
+	p := assignments(&calls)
+	i := parse.NewInput("let a = 1; let b = 2;")
+	_, f := p(i)
+	as.Nil(f)
+
+	for n := 0; n < 20; n++ {
+		relocations = 0
+		i = i.Edit(parse.Edit{Start: 0, End: 0, Text: "let z = 0; "})
+		as.Equal(0, relocations)
+
+		calls = 0
+		s, f := p(i)
+		as.Nil(f)
+		as.Equal(1, calls)
+		as.Equal(n+2, relocations)
+
+		res := s.Result.(parse.Results)
+		as.Equal(n+3, len(res))
+		as.Equal(&assignment{name: "b", value: "2", pos: 11*n + 22}, res[n+2])
+	}
+
+	// outcomes that go unused across too many Edits are discarded
+	for n := 0; n < 20; n++ {
+		i = i.Edit(parse.Edit{Start: 0, End: 0, Text: "let z = 0; "})
+	}
+	calls = 0
+	_, f = p(i)
+	as.Nil(f)
+	as.Equal(43, calls)
+}
+
+func TestEditLookahead(t *testing.T) {
+	as := NewAssert(t)
+
+	calls := 0
+	p := assignments(&calls)
+	i := parse.NewInput("let a = 1; let b = 2;")
+	_, f := p(i)
+	as.Nil(f)
+
+	calls = 0
+	// the second statement examined the end of the input while matching
+	// its trailing whitespace, so it is performed again
+	i = i.Edit(parse.Edit{Start: 21, End: 21, Text: " let"})
+	_, f = p(i)
+	as.NotNil(f)
+	as.Equal(2, calls)
+
+	calls = 0
+	i = i.Edit(parse.Edit{Start: 8, End: 9, Text: "1x"})
+	_, f = p(i)
+	as.NotNil(f)
+	as.Equal(1, calls)
+}
+
+func TestEditFailure(t *testing.T) {
+	as := NewAssert(t)
+
+	calls := 0
+	p := assignments(&calls)
+	i := parse.NewInput("let a = 1; let b = ;")
+	s, f := p(i)
+	as.Nil(s)
+	as.Equal(11, f.Input.Offset())
+
+	i = i.Edit(parse.Edit{Start: 0, End: 0, Text: "let z = 0; "})
+	s, f = p(i)
+	as.Nil(s)
+	as.Equal(22, f.Input.Offset())
+	as.Equal("let b = ;", f.Input.String())
+}
+
+func TestEditExcerpt(t *testing.T) {
+	as := NewAssert(t)
+
+	calls := 0
+	p := parse.Parser(func(i parse.Input) (*parse.Success, *parse.Failure) {
+		calls++
+		return parse.String("x")(i)
+	}).Memo()
+
+	i := parse.NewInput("abcdef")
+	_, f := p(i)
+	as.EqualError(f.Error, "expected string x, got abcdef")
+
+	calls = 0
+	i = i.Edit(parse.Edit{Start: 3, End: 6, Text: "xyz"})
+	_, f = p(i)
+	as.EqualError(f.Error, "expected string x, got abcxyz")
+	as.Equal(1, calls)
+}
+
+func TestEditPosition(t *testing.T) {
+	as := NewAssert(t)
+
+	calls := 0
+	group := parse.Parser(func(i parse.Input) (*parse.Success, *parse.Failure) {
+		calls++
+		return parse.Bracketed("(", ")", parse.RegExp("[a-z]+"))(i)
+	}).Memo()
+	p := parse.RegExp(`[a-z]+\n`).ZeroOrMore().Then(group)
+
+	i := parse.NewInput("a\n(b")
+	_, f := p(i)
+	as.EqualError(f.Error, "unclosed '(' opened at 2:1")
+
+	// the Edit leaves the group's line intact
+	calls = 0
+	i = i.Edit(parse.Edit{Start: 0, End: 0, Text: "x"})
+	_, f = p(i)
+	as.EqualError(f.Error, "unclosed '(' opened at 2:1")
+	as.Equal(0, calls)
+
+	// the Edit moves the group to another line
+	i = i.Edit(parse.Edit{Start: 0, End: 0, Text: "x\n"})
+	_, f = p(i)
+	as.EqualError(f.Error, "unclosed '(' opened at 3:1")
+	as.Equal(1, calls)
+}
+
+func TestEditIndent(t *testing.T) {
+	as := NewAssert(t)
+
+	guarded := parse.IndentGuard(parse.Equal, 2).Then(parse.String("a"))
+	p := parse.RegExp("[x \n]*").Then(guarded.Memo()).Left(parse.EOF)
+
+	i := parse.NewInput("  a")
+	s, f := p(i)
+	as.SuccessResult(s, f, "a")
+
+	i = i.Edit(parse.Edit{Start: 0, End: 0, Text: "x"})
+	s, f = p(i)
+	fs, ff := p.Parse(i.String())
+	as.Equal(fs, s)
+	as.Equal(ff.Error, f.Error)
+	as.ErrorIs(f.Error, parse.ErrIncorrectIndent)
+
+	i = parse.NewInput(" \n  a")
+	s, f = p(i)
+	as.SuccessResult(s, f, "a")
+	i = i.Edit(parse.Edit{Start: 0, End: 0, Text: "x"})
+	s, f = p(i)
+	as.SuccessResult(s, f, "a")
+	as.Equal(6, s.Remaining.Offset())
+}
+
+func TestInvalidEdit(t *testing.T) {
+	as := NewAssert(t)
+	i := parse.NewInput("hello")
+	as.PanicsWithError(
+		"invalid edit of 5 bytes: 3 to 9",
+		func() { i.Edit(parse.Edit{Start: 3, End: 9}) },
+	)
+
+	i = parse.Input{}.Edit(parse.Edit{Text: "hi"})
+	as.Equal("hi", i.String())
+	as.PanicsWithError(
+		"invalid edit of 0 bytes: 0 to 1",
+		func() { parse.Input{}.Edit(parse.Edit{End: 1}) },
+	)
+}
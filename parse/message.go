@@ -0,0 +1,104 @@
+package parse
+
+import "fmt"
+
+type (
+	// Error is the error of the Failures that built-in Parsers produce. Err
+	// is the sentinel error that identifies what went wrong, Args are its
+	// details, and Got is an excerpt of the Input where it went wrong. An
+	// Error describes itself using the English Catalog, but can be rendered
+	// in other languages by any Formatter. Args that are themselves errors
+	// are rendered by the same Catalog
+	Error struct {
+		Err  error
+		Args []any
+		Got  string
+	}
+
+	// Formatter renders the errors of Failures as messages
+	Formatter interface {
+		Format(err error) string
+	}
+
+	// Catalog is a Formatter that renders the errors of Failures using the
+	// message formats keyed by their sentinel errors. The format is given an
+	// error's Args followed by its Got excerpt, and will usually refer to
+	// them by explicit index, so that translations can reorder them. Errors
+	// that are missing from a Catalog are rendered using English
+	Catalog map[error]string
+)
+
+// English is the Catalog of messages that built-in errors describe
+// themselves with
+var English = Catalog{
+	ErrExpectedEndOfFile: "expected end of file, got %[1]s",
+	ErrExpectedPattern:   "expected pattern: %[1]s, got %[2]s",
+	ErrExpectedString:    "expected string %[1]s, got %[2]s",
+	ErrExpectedLabel:     "expected %[1]s, got %[2]s",
+	ErrUnexpectedInput:   "unexpected input: %[1]s",
+	ErrExpectedMatches:   "expected %[1]d matches, got %[2]d: %[3]s",
+	ErrExpectedAtLeast:   "expected at least %[1]d matches, got %[2]d: %[3]s",
+	ErrIncorrectIndent: "incorrect indentation " +
+		"(got %[1]d, expected %[2]s %[3]d)",
+	ErrLimitExceeded:     "parse limit exceeded: %[1]s (max %[2]d)",
+	ErrActionPanicked:    "action panicked: %[1]v",
+	ErrDidYouMean:        "%[1]s (did you mean %[2]q?)",
+	ErrUnclosedDelimiter: "unclosed '%[1]s' opened at %[2]s",
+	ErrMismatchedDelimiter: "mismatched '%[1]s', expected '%[2]s' " +
+		"to close '%[3]s' opened at %[4]s",
+}
+
+// Error messages
+const (
+	ErrWrappedExpectation = "%s, got %s"
+	ErrWrappedDetail      = "%s: %s, got %s"
+)
+
+// Error describes the Error using the English Catalog
+func (e *Error) Error() string {
+	return English.Format(e)
+}
+
+// Unwrap returns the sentinel error that the Error wraps
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Format renders the provided error using the Catalog. Errors that were not
+// produced by built-in Parsers are rendered as they describe themselves
+func (c Catalog) Format(err error) string {
+	switch err := err.(type) {
+	case *Error:
+		args := make([]any, 0, len(err.Args)+1)
+		for _, a := range err.Args {
+			if e, ok := a.(error); ok {
+				a = c.Format(e)
+			}
+			args = append(args, a)
+		}
+		return c.format(err.Err, append(args, err.Got))
+	case *LimitError:
+		return c.format(ErrLimitExceeded, []any{err.Limit, err.Max})
+	case *PanicError:
+		return c.format(ErrActionPanicked, []any{err.Value})
+	case *SuggestionError:
+		return c.format(ErrDidYouMean, []any{c.Format(err.Err), err.Literal})
+	default:
+		return err.Error()
+	}
+}
+
+func (c Catalog) format(err error, args []any) string {
+	if msg, ok := c[err]; ok {
+		return fmt.Sprintf(msg, args...)
+	}
+	if msg, ok := English[err]; ok {
+		return fmt.Sprintf(msg, args...)
+	}
+	got := args[len(args)-1]
+	if len(args) == 1 {
+		return fmt.Sprintf(ErrWrappedExpectation, err, got)
+	}
+	detail := fmt.Sprint(args[:len(args)-1]...)
+	return fmt.Sprintf(ErrWrappedDetail, err, detail, got)
+}
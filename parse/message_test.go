@@ -0,0 +1,58 @@
+package parse_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+var french = parse.Catalog{
+	parse.ErrExpectedString:    "chaîne %[1]s attendue, reçu %[2]s",
+	parse.ErrExpectedEndOfFile: "fin de fichier attendue, reçu %[1]s",
+	parse.ErrLimitExceeded:     "limite dépassée (%[2]d)",
+}
+
+func TestCatalog(t *testing.T) {
+	as := NewAssert(t)
+
+	_, f := parse.String("hello").Parse("goodbye")
+	as.Equal("chaîne hello attendue, reçu goodbye", french.Format(f.Error))
+	as.Equal("expected string hello, got goodbye", f.Error.Error())
+	as.Equal(f.Error.Error(), parse.English.Format(f.Error))
+
+	_, f = parse.String("a").EOF().Parse("ab")
+	as.Equal("fin de fichier attendue, reçu b", french.Format(f.Error))
+
+	_, f = parse.RegExp(`[0-9]+`).Parse("x")
+	as.Equal("expected pattern: [0-9]+, got x", french.Format(f.Error))
+
+	_, f = parse.String("a").ParseContext(
+		context.Background(), "aa", parse.Limits{MaxInputBytes: 1},
+	)
+	as.Equal("limite dépassée (1)", french.Format(f.Error))
+
+	_, f = parse.Fail("custom %d", 42).Parse("")
+	as.Equal("custom 42", french.Format(f.Error))
+}
+
+func TestError(t *testing.T) {
+	as := NewAssert(t)
+
+	errKeyword := errors.New("expected keyword")
+	err := parse.NewInput("let x").Expected(errKeyword, "if")
+	as.EqualError(err, "expected keyword: if, got let x")
+	as.ErrorIs(err, errKeyword)
+
+	err = parse.NewInput("").Expected(errKeyword)
+	as.EqualError(err, "expected keyword, got ")
+
+	var pe *parse.Error
+	_, f := parse.String("a").Count(2).Parse("ab")
+	as.True(errors.As(f.Error, &pe))
+	as.Equal(parse.ErrExpectedMatches, pe.Err)
+	as.Equal([]any{2, 1, pe.Args[2]}, pe.Args)
+	as.ErrorIs(pe.Args[2].(error), parse.ErrExpectedString)
+	as.Equal("b", pe.Got)
+}
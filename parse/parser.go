@@ -1,28 +1,54 @@
 package parse
 
+import "context"
+
 type (
 	// Parser is the signature for a parsing node
 	Parser func(Input) (*Success, *Failure)
 
 	// Success is the structure returned if the Parser is able to
 	// successfully match its Input. Remaining is what remains unparsed
-	// from the original Input value
+	// from the original Input value. Diagnostics are the Failures that
+	// were recovered from in order to produce the Result
 	Success struct {
-		Result    any
-		Remaining Input
+		Result      any
+		Remaining   Input
+		Diagnostics []*Diagnostic
 	}
 
 	// Failure is the structure returned if the Parser is not able to
-	// successfully match its Input
+	// successfully match its Input. Diagnostics are the Failures that were
+	// recovered from before the Parser failed
 	Failure struct {
 		Error error
 		Input
+		Diagnostics []*Diagnostic
 	}
 )
 
 // Parse uses the current Parser to match the provided string
 func (p Parser) Parse(s string) (*Success, *Failure) {
-	return p(Input(s))
+	return p(NewInput(s))
+}
+
+// Diagnose uses the current Parser to match the provided string, returning a
+// Report that includes every Diagnostic, even if the Parser fails outright
+func (p Parser) Diagnose(s string) *Report {
+	return Diagnose(p, NewInput(s))
+}
+
+// ParseContext uses the current Parser to match the provided string, subject
+// to the provided Limits and to the cancellation of the provided Context
+func (p Parser) ParseContext(
+	ctx context.Context, s string, l Limits,
+) (*Success, *Failure) {
+	return ParseContext(ctx, p, NewInput(s), l)
+}
+
+// Complete returns what the current Parser would accept at the specified
+// offset of the provided string
+func (p Parser) Complete(s string, offset int) []Expectation {
+	return Complete(p, s, offset)
 }
 
 // Return returns a new Parser. This Parser consumes none of the Input, but
@@ -55,6 +81,23 @@ func (p Parser) Fail(msg string, args ...any) Parser {
 	return p.Then(Fail(msg, args...))
 }
 
+// Validate returns a new Parser that matches this Parser, but that fails at
+// the start of its match if the provided Validator rejects its result
+func (p Parser) Validate(v Validator) Parser {
+	return Validate(p, v)
+}
+
+// Where returns a new Parser that matches this Parser, but that fails at
+// the start of its match unless its result satisfies the provided Condition
+func (p Parser) Where(c Condition, label string) Parser {
+	return Where(p, c, label)
+}
+
+// FailWith returns a Parser node that fails with the provided error
+func (p Parser) FailWith(err error) Parser {
+	return p.Then(FailWith(err))
+}
+
 // Satisfy returns a new Parser. This Parser consumes enough of the Input to
 // satisfy the provided Predicate and returns Success on a match
 func (p Parser) Satisfy(pred Predicate) Parser {
@@ -90,6 +133,119 @@ func (p Parser) DefaultTo(r any) Parser {
 	return DefaultTo(p, r)
 }
 
+// Recover returns a new Parser that matches this Parser, or records its
+// Failure as a Diagnostic and skips ahead to where the sync Parser matches
+func (p Parser) Recover(sync Parser) Parser {
+	return Recover(p, sync)
+}
+
+// Safe returns a new Parser that matches this Parser, but that fails rather
+// than panicking if any of the actions that it performs panic
+func (p Parser) Safe() Parser {
+	return Safe(p)
+}
+
+// Suggest returns a new Parser that matches this Parser, but that suggests
+// the closest literal that it expected if it fails
+func (p Parser) Suggest() Parser {
+	return Suggest(p)
+}
+
+// Label returns a new Parser that matches this Parser, but that describes it
+// by name in Failures and Completions
+func (p Parser) Label(name string) Parser {
+	return Label(name, p)
+}
+
+// Indented returns a new Parser that matches this Parser only if it starts
+// at an indentation greater than the reference indentation
+func (p Parser) Indented() Parser {
+	return Indented(p)
+}
+
+// SameIndent returns a new Parser that matches this Parser only if it
+// starts at the reference indentation
+func (p Parser) SameIndent() Parser {
+	return SameIndent(p)
+}
+
+// Block returns a new Parser, the result of which is the Combined set of
+// values matched by this Parser performed one or more times, each beginning
+// a line at the same indentation
+func (p Parser) Block() Parser {
+	return Block(p)
+}
+
+// LineFold returns a new Parser that matches this Parser, which may continue
+// onto lines that are indented more than the one on which it started
+func (p Parser) LineFold() Parser {
+	return LineFold(p)
+}
+
+// Memo returns a new Parser that remembers the outcome of this Parser at
+// every offset of an Input's source text
+func (p Parser) Memo() Parser {
+	return Memo(p)
+}
+
+// Left returns a new Parser that matches this Parser followed by the other
+// Parser. The result is that of this Parser
+func (p Parser) Left(other Parser) Parser {
+	return Left(p, other)
+}
+
+// Right returns a new Parser that matches this Parser followed by the other
+// Parser. The result is that of the other Parser
+func (p Parser) Right(other Parser) Parser {
+	return Right(p, other)
+}
+
+// Enclosed returns a new Parser that matches this Parser when it is
+// surrounded by the open and close Parsers. The result is that of this Parser
+func (p Parser) Enclosed(open Parser, close Parser) Parser {
+	return Enclosed(open, close, p)
+}
+
+// Bracketed returns a new Parser that matches this Parser when it is
+// surrounded by the open and close delimiters, and that reports where the
+// open delimiter was found if the close delimiter is missing. It can't detect
+// mismatched delimiters, which the Bracketed method of Brackets can
+func (p Parser) Bracketed(open, close string) Parser {
+	return Bracketed(open, close, p)
+}
+
+// ReportUnclosed returns a new Parser that matches this Parser, but that
+// fails with any unclosed or mismatched delimiter that a Bracketed Parser
+// encountered where this Parser failed or beyond
+func (p Parser) ReportUnclosed() Parser {
+	return ReportUnclosed(p)
+}
+
+// Skip returns a new Parser that matches this Parser, but produces a Skipped
+// result that Concat and Combine will drop
+func (p Parser) Skip() Parser {
+	return Skip(p)
+}
+
+// Peek returns a new Parser that matches this Parser without consuming any of
+// the Input
+func (p Parser) Peek() Parser {
+	return Peek(p)
+}
+
+// NotBefore returns a new Parser that matches this Parser only if the other
+// Parser doesn't match what follows it. The result is that of this Parser
+func (p Parser) NotBefore(other Parser) Parser {
+	return NotBefore(p, other)
+}
+
+// ManyTill returns a new Parser, the result of which is the Combined set of
+// values matched by this Parser, performed zero or more times until the end
+// Parser matches
+func (p Parser) ManyTill(end Parser) Parser {
+	return ManyTill(p, end)
+}
+
 // Concat returns a new Parser, the result of which is generated by
 // concatenating the Results of the provided Parsers
 func (p Parser) Concat(other Parser) Parser {
@@ -114,9 +270,87 @@ func (p Parser) ZeroOrMore() Parser {
 	return ZeroOrMore(p)
 }
 
+// Count returns a new Parser, the result of which is the Combined set of
+// values matched by this Parser being performed exactly n times
+func (p Parser) Count(n int) Parser {
+	return Count(n, p)
+}
+
+// AtMost returns a new Parser, the result of which is the Combined set of
+// values matched by this Parser being performed up to n times
+func (p Parser) AtMost(n int) Parser {
+	return AtMost(n, p)
+}
+
+// Between returns a new Parser, the result of which is the Combined set of
+// values matched by this Parser being performed at least min times, and up
+// to max times
+func (p Parser) Between(min, max int) Parser {
+	return Between(min, max, p)
+}
+
 // Delimited returns a new Parser, the result of which is the Combined set of
 // values matched by the provided Parser and delimited by the provided
 // Delimiter, performed one or more times
 func (p Parser) Delimited(d Delimiter) Parser {
 	return Delimited(p, d)
 }
+
+// SepBy returns a new Parser, the result of which is the Combined set of
+// values matched by this Parser and delimited by the provided Delimiter,
+// performed zero or more times
+func (p Parser) SepBy(d Delimiter) Parser {
+	return SepBy(p, d)
+}
+
+// SepEndBy returns a new Parser, the result of which is the Combined set of
+// values matched by this Parser and delimited by the provided Delimiter,
+// performed zero or more times. A trailing Delimiter is allowed
+func (p Parser) SepEndBy(d Delimiter) Parser {
+	return SepEndBy(p, d)
+}
+
+// EndBy returns a new Parser, the result of which is the Combined set of
+// values matched by this Parser, performed zero or more times, with each
+// match followed by the provided Delimiter
+func (p Parser) EndBy(d Delimiter) Parser {
+	return EndBy(p, d)
+}
+
+// DelimitedKeep returns a new Parser that behaves like Delimited, except
+// that the results of the Delimiters are retained between the values
+func (p Parser) DelimitedKeep(d Delimiter) Parser {
+	return DelimitedKeep(p, d)
+}
+
+// SepByKeep returns a new Parser that behaves like SepBy, except that the
+// results of the Delimiters are retained between the values
+func (p Parser) SepByKeep(d Delimiter) Parser {
+	return SepByKeep(p, d)
+}
+
+// SepEndByKeep returns a new Parser that behaves like SepEndBy, except that
+// the results of the Delimiters, including any trailing one, are retained
+func (p Parser) SepEndByKeep(d Delimiter) Parser {
+	return SepEndByKeep(p, d)
+}
+
+// EndByKeep returns a new Parser that behaves like EndBy, except that the
+// results of the Delimiters are retained after each value
+func (p Parser) EndByKeep(d Delimiter) Parser {
+	return EndByKeep(p, d)
+}
+
+// ChainL1 returns a new Parser that matches one or more values with this
+// Parser, separated by the provided operator Parser, and folds the results
+// from the left using the provided Folder
+func (p Parser) ChainL1(op Parser, fn Folder) Parser {
+	return ChainL1(p, op, fn)
+}
+
+// ChainR1 returns a new Parser that matches one or more values with this
+// Parser, separated by the provided operator Parser, and folds the results
+// from the right using the provided Folder
+func (p Parser) ChainR1(op Parser, fn Folder) Parser {
+	return ChainR1(p, op, fn)
+}
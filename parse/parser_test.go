@@ -27,12 +27,18 @@ func TestParserSatisfy(t *testing.T) {
 	as := NewAssert(t)
 
 	p := parse.String("hello").Satisfy(func(i parse.Input) (int, error) {
-		if i[0] == '!' {
+		if i.String()[0] == '!' {
 			return 1, nil
 		}
 		return 0, errors.New("mismatch")
 	})
 
 	s, f := p.Parse("hello!")
-	as.SuccessResult(s, f, parse.Input("!"))
+	as.SuccessResult(s, f, "!")
+
+	s, f = p.Parse("hello?")
+	as.FailureError(s, f, "mismatch")
+	as.Equal("?", f.String())
+	as.Equal(5, f.Offset())
+	as.Equal(f.Input, f.Advance(0))
 }
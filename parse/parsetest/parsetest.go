@@ -0,0 +1,162 @@
+// Package parsetest provides assertions for testing Parsers and the
+// grammars that are built from them
+package parsetest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	// Assertions extends testify's Assertions with checks of the Successes
+	// and Failures that Parsers return
+	Assertions struct {
+		*assert.Assertions
+	}
+
+	// Case describes the expected outcome of parsing an Input. A Case
+	// expects a Failure if any of Error, Message, or Position are set.
+	// Otherwise, it expects a Success with the provided Result, leaving the
+	// provided Remaining text unparsed
+	Case struct {
+		// Input is the text to be parsed
+		Input string
+
+		// Result is the expected result of a Success
+		Result any
+
+		// Remaining is the text that a Success is expected to leave
+		// unparsed. It is empty if all of the Input should be consumed
+		Remaining string
+
+		// Error is an error that the Failure's error is expected to wrap
+		Error error
+
+		// Message is the expected message of the Failure's error
+		Message string
+
+		// Position is the expected line:column Position of the Failure
+		Position string
+	}
+)
+
+// New returns Assertions that report to the provided test
+func New(t assert.TestingT) *Assertions {
+	return &Assertions{
+		Assertions: assert.New(t),
+	}
+}
+
+// Run parses the Input of each Case with the provided Parser, checking the
+// outcome in a subtest that is named by the Case's Input
+func Run(t *testing.T, p parse.Parser, cases ...Case) {
+	for _, c := range cases {
+		c := c
+		t.Run(c.Input, func(t *testing.T) {
+			New(t).Case(p, c)
+		})
+	}
+}
+
+// Case parses the Case's Input with the provided Parser and checks that
+// the outcome matches the Case's expectations
+func (as *Assertions) Case(p parse.Parser, c Case) bool {
+	s, f := p.Parse(c.Input)
+	if !c.failing() {
+		return as.SuccessResult(s, f, c.Result) &&
+			as.Equal(c.Remaining, s.Remaining.String())
+	}
+	if !as.Failure(s, f) {
+		return false
+	}
+	res := true
+	if c.Error != nil {
+		res = as.ErrorIs(f.Error, c.Error) && res
+	}
+	if c.Message != "" {
+		res = as.EqualError(f.Error, c.Message) && res
+	}
+	if c.Position != "" {
+		pos := f.Input.Position().String()
+		res = as.Equal(c.Position, pos) && res
+	}
+	return res
+}
+
+// Success asserts that a Parser succeeded
+func (as *Assertions) Success(s *parse.Success, f *parse.Failure) bool {
+	if f != nil {
+		return as.Fail(fmt.Sprintf("unexpected failure: %s", f.Error))
+	}
+	return as.NotNil(s)
+}
+
+// SuccessResult asserts that a Parser succeeded with the provided result
+func (as *Assertions) SuccessResult(
+	s *parse.Success, f *parse.Failure, r any,
+) bool {
+	return as.Success(s, f) && as.Equal(r, s.Result)
+}
+
+// SuccessResults asserts that a Parser succeeded with Results consisting
+// of the provided values
+func (as *Assertions) SuccessResults(
+	s *parse.Success, f *parse.Failure, r ...any,
+) bool {
+	if !as.Success(s, f) || !as.IsType(parse.Results{}, s.Result) {
+		return false
+	}
+	if len(r) == 0 {
+		return as.Empty(s.Result)
+	}
+	return as.Equal(parse.Results(r), s.Result)
+}
+
+// Failure asserts that a Parser failed
+func (as *Assertions) Failure(s *parse.Success, f *parse.Failure) bool {
+	if s != nil {
+		return as.Fail(fmt.Sprintf("unexpected success: %v", s.Result))
+	}
+	return as.NotNil(f)
+}
+
+// FailureWrapped asserts that a Parser failed with an expectation that
+// wraps the provided error. The final argument is the excerpt of the
+// Input that was found instead, and the arguments that precede it are the
+// expectation's details
+func (as *Assertions) FailureWrapped(
+	s *parse.Success, f *parse.Failure, err error, args ...string,
+) bool {
+	return as.Failure(s, f) && as.Wrapped(f.Error, err, args...)
+}
+
+// FailureError asserts that a Parser failed with the provided message
+func (as *Assertions) FailureError(
+	s *parse.Success, f *parse.Failure, msg string, args ...any,
+) bool {
+	return as.Failure(s, f) && as.EqualError(f.Error, msg, args...)
+}
+
+// Wrapped asserts that an error is an expectation that wraps the provided
+// error. The final argument is the excerpt of the Input that was found
+// instead, and the arguments that precede it are the expectation's details
+func (as *Assertions) Wrapped(err error, wrapped error, args ...string) bool {
+	if !as.ErrorIs(err, wrapped) || !as.NotEmpty(args) {
+		return false
+	}
+	e := &parse.Error{
+		Err: wrapped,
+		Got: args[len(args)-1],
+	}
+	for _, a := range args[:len(args)-1] {
+		e.Args = append(e.Args, a)
+	}
+	return as.EqualError(err, parse.English.Format(e))
+}
+
+func (c *Case) failing() bool {
+	return c.Error != nil || c.Message != "" || c.Position != ""
+}
@@ -0,0 +1,105 @@
+package parsetest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+	"github.com/kode4food/kombi/parse/parsetest"
+	"github.com/stretchr/testify/assert"
+)
+
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func number() parse.Parser {
+	return parse.RegExp(`[0-9]+`)
+}
+
+func TestRun(t *testing.T) {
+	list := number().Delimited(parse.String(","))
+	parsetest.Run(t, list,
+		parsetest.Case{Input: "1,2", Result: parse.Results{"1", "2"}},
+		parsetest.Case{
+			Input:     "1,2;",
+			Result:    parse.Results{"1", "2"},
+			Remaining: ";",
+		},
+		parsetest.Case{
+			Input:    "x",
+			Error:    parse.ErrExpectedPattern,
+			Message:  "expected pattern: [0-9]+, got x",
+			Position: "1:1",
+		},
+		parsetest.Case{Input: "\n\nx", Position: "1:1"},
+	)
+}
+
+func TestCase(t *testing.T) {
+	as := assert.New(t)
+
+	r := &recorder{}
+	pt := parsetest.New(r)
+	as.True(pt.Case(number(), parsetest.Case{Input: "12", Result: "12"}))
+	as.Empty(r.errors)
+
+	as.False(pt.Case(number(), parsetest.Case{Input: "12", Result: "13"}))
+	as.Len(r.errors, 1)
+
+	as.False(pt.Case(number(), parsetest.Case{Input: "12x", Result: "12"}))
+	as.Len(r.errors, 2)
+
+	as.False(pt.Case(number(), parsetest.Case{
+		Input: "12", Error: parse.ErrExpectedPattern,
+	}))
+	as.Contains(r.errors[2], "unexpected success: 12")
+
+	as.False(pt.Case(number(), parsetest.Case{Input: "x", Result: "1"}))
+	as.Contains(r.errors[3], "unexpected failure: expected pattern")
+
+	as.False(pt.Case(number(), parsetest.Case{
+		Input: "x", Error: parse.ErrExpectedString, Position: "1:2",
+	}))
+	as.Len(r.errors, 6)
+}
+
+func TestAssertions(t *testing.T) {
+	as := parsetest.New(t)
+
+	s, f := number().Parse("42")
+	as.Success(s, f)
+	as.SuccessResult(s, f, "42")
+
+	s, f = number().ZeroOrMore().Parse("")
+	as.SuccessResults(s, f)
+
+	s, f = number().Parse("x")
+	as.Failure(s, f)
+	as.FailureWrapped(s, f, parse.ErrExpectedPattern, "[0-9]+", "x")
+	as.FailureError(s, f, "expected pattern: [0-9]+, got x")
+
+	s, f = parse.EOF.Parse("x")
+	as.FailureWrapped(s, f, parse.ErrExpectedEndOfFile, "x")
+
+	b := parse.Brackets{"(": ")", "[": "]"}
+	_, bf := b.Bracketed("(", number()).Parse("(1]")
+	as.Wrapped(bf.Error, parse.ErrMismatchedDelimiter,
+		"]", ")", "(", "1:1", "]",
+	)
+
+	r := &recorder{}
+	pt := parsetest.New(r)
+	as.False(pt.Wrapped(f.Error, parse.ErrExpectedEndOfFile))
+	as.False(pt.SuccessResults(s, f, "x"))
+	s, f = number().Parse("42")
+	as.False(pt.SuccessResults(s, f, "42"))
+	as.False(pt.Wrapped(bf.Error, parse.ErrMismatchedDelimiter,
+		"]", "}", "(", "1:1", "]",
+	))
+	as.Len(r.errors, 4)
+}
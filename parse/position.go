@@ -0,0 +1,52 @@
+package parse
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// Position is the line and column of an Input within its source text. Both
+// are counted from 1, and columns are counted in characters
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Position returns the line and column of the Input within its source text
+func (i Input) Position() Position {
+	if i.source == nil {
+		return Position{Line: 1, Column: 1}
+	}
+	line := i.line()
+	start := i.lineStarts()[line]
+	return Position{
+		Line:   line + 1,
+		Column: utf8.RuneCountInString(i.text[start:i.offset]) + 1,
+	}
+}
+
+// String returns the Position formatted as line:column
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+func (i Input) line() int {
+	i.positioned = true
+	starts := i.lineStarts()
+	return sort.Search(len(starts), func(l int) bool {
+		return starts[l] > i.offset
+	}) - 1
+}
+
+func (s *source) lineStarts() []int {
+	if s.lines == nil {
+		s.lines = []int{0}
+		for idx := 0; idx < len(s.text); idx++ {
+			if s.text[idx] == '\n' {
+				s.lines = append(s.lines, idx+1)
+			}
+		}
+	}
+	return s.lines
+}
@@ -0,0 +1,20 @@
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+func TestPosition(t *testing.T) {
+	as := NewAssert(t)
+
+	i := parse.NewInput("ab\ncdé\n\nf")
+	as.Equal(parse.Position{Line: 1, Column: 1}, i.Position())
+	as.Equal(parse.Position{Line: 1, Column: 3}, i.Advance(2).Position())
+	as.Equal(parse.Position{Line: 2, Column: 1}, i.Advance(3).Position())
+	as.Equal(parse.Position{Line: 2, Column: 4}, i.Advance(7).Position())
+	as.Equal(parse.Position{Line: 3, Column: 1}, i.Advance(8).Position())
+	as.Equal("4:2", i.Advance(10).Position().String())
+	as.Equal("1:1", parse.Input{}.Position().String())
+}
@@ -0,0 +1,122 @@
+package parse
+
+import "unicode/utf8"
+
+type (
+	// Diagnostic records a Failure that a Recover Parser recovered from,
+	// along with the text it skipped in order to resynchronize. Start is
+	// the offset where the skipped text begins, which can precede the
+	// offset where the Failure occurred
+	Diagnostic struct {
+		*Failure
+		Skipped string
+		Start   int
+	}
+
+	// Report is the outcome of Diagnosing an Input. Result is the possibly
+	// partial result of a successful parse, and Diagnostics includes every
+	// Failure that was recovered from. If the parse failed outright, its
+	// Failure is the last Diagnostic and Result is nil
+	Report struct {
+		Result      any
+		Remaining   Input
+		Diagnostics []*Diagnostic
+	}
+)
+
+// Recover returns a new Parser that attempts to match the provided Parser.
+// If that Parser fails, its Failure is recorded as a Diagnostic, and the
+// Input is skipped up to the point where the sync Parser matches, without
+// consuming the sync match itself. The result of a recovery is Skipped, so
+// that it drops out of any Concatenated or Combined results. If the sync
+// Parser matches where the provided Parser was attempted, nothing would be
+// skipped, so the Failure is returned instead
+func Recover(p Parser, sync Parser) Parser {
+	return func(i Input) (*Success, *Failure) {
+		s, f := p(i)
+		if f == nil || i.Len() == 0 {
+			return s, f
+		}
+		rem := skipUntil(i, sync)
+		if rem.offset == i.offset {
+			return nil, f
+		}
+		return &Success{
+			Result:    Skipped,
+			Remaining: rem,
+			Diagnostics: concatDiagnostics(f.Diagnostics, []*Diagnostic{{
+				Failure: f,
+				Skipped: i.Until(rem),
+				Start:   i.offset,
+			}}),
+		}, nil
+	}
+}
+
+// Diagnose uses the provided Parser to match the Input, collecting all of
+// the Diagnostics into a Report
+func Diagnose(p Parser, i Input) *Report {
+	s, f := p(i)
+	if f != nil {
+		return &Report{
+			Remaining: f.Input,
+			Diagnostics: concatDiagnostics(f.Diagnostics, []*Diagnostic{{
+				Failure: f,
+				Start:   f.offset,
+			}}),
+		}
+	}
+	return &Report{
+		Result:      s.Result,
+		Remaining:   s.Remaining,
+		Diagnostics: s.Diagnostics,
+	}
+}
+
+// HasErrors returns whether any Diagnostics were collected
+func (r *Report) HasErrors() bool {
+	return len(r.Diagnostics) > 0
+}
+
+// Errors returns the errors of all collected Diagnostics
+func (r *Report) Errors() []error {
+	res := make([]error, len(r.Diagnostics))
+	for i, d := range r.Diagnostics {
+		res[i] = d.Error
+	}
+	return res
+}
+
+func skipUntil(i Input, sync Parser) Input {
+	for i.Len() > 0 {
+		if _, f := sync(i); f == nil {
+			return i
+		}
+		_, n := utf8.DecodeRuneInString(i.String())
+		i = i.Advance(n)
+	}
+	return i
+}
+
+// withDiagnostics returns a copy of the Failure that also carries the
+// provided Diagnostics, which were recovered from before it was produced
+func (f *Failure) withDiagnostics(d []*Diagnostic) *Failure {
+	if len(d) == 0 {
+		return f
+	}
+	res := *f
+	res.Diagnostics = concatDiagnostics(d, f.Diagnostics)
+	return &res
+}
+
+func concatDiagnostics(l, r []*Diagnostic) []*Diagnostic {
+	if len(l) == 0 {
+		return r
+	}
+	if len(r) == 0 {
+		return l
+	}
+	res := make([]*Diagnostic, 0, len(l)+len(r))
+	res = append(res, l...)
+	return append(res, r...)
+}
@@ -0,0 +1,124 @@
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+func TestRecover(t *testing.T) {
+	as := NewAssert(t)
+
+	ws := parse.RegExp(" *").Skip()
+	stmt := parse.String("let").Concat(ws).
+		Concat(parse.RegExp("[a-z]+")).Concat(ws).
+		Concat(parse.String("=").Skip()).Concat(ws).
+		Concat(parse.RegExp("[0-9]+"))
+	program := ws.Then(stmt.Recover(parse.String(";")).EndBy(
+		parse.String(";").Concat(ws),
+	)).Left(parse.EOF)
+
+	r := program.Diagnose("let x = 1; let = 2; let y = 3;")
+	as.True(r.HasErrors())
+	as.Equal(parse.Results{"let", "x", "1", "let", "y", "3"}, r.Result)
+	as.Equal(1, len(r.Diagnostics))
+
+	d := r.Diagnostics[0]
+	as.Equal("let = 2", d.Skipped)
+	as.Equal(11, d.Start)
+	as.Equal(15, d.Offset())
+	as.Equal("= 2; let y = 3;", d.Input.String())
+	as.Wrapped(d.Error,
+		parse.ErrExpectedPattern, "[a-z]+", "= 2; let y = 3;",
+	)
+	as.Equal([]error{d.Error}, r.Errors())
+
+	r = program.Diagnose("let a = 1; let b = 2;")
+	as.False(r.HasErrors())
+	as.Equal(parse.Results{"let", "a", "1", "let", "b", "2"}, r.Result)
+}
+
+func TestRecoverAtEnd(t *testing.T) {
+	as := NewAssert(t)
+
+	p := parse.String("a").Recover(parse.String(";"))
+	s, f := p.Parse("")
+	as.FailureWrapped(s, f, parse.ErrExpectedString, "a", "")
+
+	s, f = p.Parse("bbb")
+	as.SuccessResult(s, f, parse.Skipped)
+	as.Equal("", s.Remaining.String())
+	as.Equal("bbb", s.Diagnostics[0].Skipped)
+}
+
+func TestRecoverAtSync(t *testing.T) {
+	as := NewAssert(t)
+
+	p := parse.String("a").Recover(parse.String(";")).ZeroOrMore()
+	s, f := p.Parse(";")
+	as.SuccessResults(s, f)
+	as.Equal(";", s.Remaining.String())
+	as.Nil(s.Diagnostics)
+
+	s, f = p.Parse("ab;")
+	as.SuccessResults(s, f, "a")
+	as.Equal(";", s.Remaining.String())
+	as.Equal("b", s.Diagnostics[0].Skipped)
+}
+
+func TestRecoverBacktrack(t *testing.T) {
+	as := NewAssert(t)
+
+	abandoned := parse.String("a").Recover(parse.String(";")).
+		Then(parse.String("!"))
+	p := abandoned.Or(parse.RegExp("[a-z;]+"))
+
+	s, f := p.Parse("xyz;")
+	as.SuccessResult(s, f, "xyz;")
+	as.Nil(s.Diagnostics)
+}
+
+func TestDiagnoseFailure(t *testing.T) {
+	as := NewAssert(t)
+
+	r := parse.String("hello").Diagnose("goodbye")
+	as.True(r.HasErrors())
+	as.Nil(r.Result)
+	as.Equal("goodbye", r.Remaining.String())
+	as.Wrapped(r.Diagnostics[0].Error,
+		parse.ErrExpectedString, "hello", "goodbye",
+	)
+}
+
+func TestDiagnoseRecoveredThenFailure(t *testing.T) {
+	as := NewAssert(t)
+
+	item := parse.RegExp("[0-9]").Recover(parse.String(","))
+	p := item.Left(parse.String(",")).OneOrMore().Left(parse.EOF)
+
+	r := p.Diagnose("1,x,3,4!")
+	as.True(r.HasErrors())
+	as.Nil(r.Result)
+	as.Equal("4!", r.Remaining.String())
+	as.Equal(2, len(r.Diagnostics))
+
+	d := r.Diagnostics[0]
+	as.Equal("x", d.Skipped)
+	as.Wrapped(d.Error, parse.ErrExpectedPattern, "[0-9]", "x,3,4!")
+
+	d = r.Diagnostics[1]
+	as.Equal("", d.Skipped)
+	as.Equal(6, d.Start)
+	as.Equal("4!", d.Input.String())
+	as.ErrorIs(d.Error, parse.ErrExpectedEndOfFile)
+}
+
+func TestRecoverRepeated(t *testing.T) {
+	as := NewAssert(t)
+
+	item := parse.RegExp("[0-9]").Recover(parse.String(","))
+	p := item.Left(parse.String(",")).Count(3)
+	s, f := p.Parse("1,x,y,")
+	as.SuccessResults(s, f, "1")
+	as.Equal(2, len(s.Diagnostics))
+}
@@ -0,0 +1,119 @@
+package parse
+
+import (
+	"errors"
+	"runtime/debug"
+)
+
+// PanicError is the error of the Failure that a Safe Parser returns when
+// one of the actions that it performs panics. Value is what the action
+// panicked with, and Stack is the stack trace at the time of the panic
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// Errors that Parsers fail with
+var (
+	ErrActionPanicked = errors.New("action panicked")
+)
+
+// Safe returns a new Parser that matches the provided Parser, but that
+// recovers from any panic raised by the Binders, Mappers, Combiners, Accept
+// functions, Predicates, Validators, and Conditions that it performs.
+// Rather than crashing, the Parser fails with a *PanicError, positioned at
+// the start of the text that the panicking action was given. Such a Failure
+// is fatal: the provided Parser is abandoned at once, without attempting
+// any of its alternatives
+func Safe(p Parser) Parser {
+	return func(i Input) (s *Success, f *Failure) {
+		if i.source == nil || i.safe {
+			return p(i)
+		}
+		i.safe = true
+		defer func() {
+			i.safe = false
+			if rec := recover(); rec != nil {
+				a, ok := rec.(*abort)
+				if !ok || !a.panicked() {
+					panic(rec)
+				}
+				s, f = nil, a.Failure
+			}
+		}()
+		return p(i)
+	}
+}
+
+// Error returns a description of the panic
+func (e *PanicError) Error() string {
+	return English.Format(e)
+}
+
+// Unwrap returns ErrActionPanicked
+func (e *PanicError) Unwrap() error {
+	return ErrActionPanicked
+}
+
+func (i Input) bind(b Binder, r any) Parser {
+	if !i.protected() {
+		return b(r)
+	}
+	defer i.recoverPanic()
+	return b(r)
+}
+
+func (i Input) mapState(fn Mapper) any {
+	if !i.protected() {
+		return fn(i.state)
+	}
+	defer i.recoverPanic()
+	return fn(i.state)
+}
+
+func (i Input) satisfy(p Predicate) (int, error) {
+	if !i.protected() {
+		return p(i)
+	}
+	defer i.recoverPanic()
+	return p(i)
+}
+
+func (i Input) validate(v Validator, r any) error {
+	if !i.protected() {
+		return v(r)
+	}
+	defer i.recoverPanic()
+	return v(r)
+}
+
+func (i Input) satisfies(c Condition, r any) bool {
+	if !i.protected() {
+		return c(r)
+	}
+	defer i.recoverPanic()
+	return c(r)
+}
+
+func (i Input) protected() bool {
+	return i.source != nil && i.safe
+}
+
+// recoverPanic must be deferred by the function that calls a user-provided
+// action, so that a panic in the action aborts the Safe Parser with a Failure
+func (i Input) recoverPanic() {
+	if rec := recover(); rec != nil {
+		if a, ok := rec.(*abort); ok {
+			panic(a)
+		}
+		i.abort(&PanicError{
+			Value: rec,
+			Stack: debug.Stack(),
+		})
+	}
+}
+
+func (a *abort) panicked() bool {
+	_, ok := a.Error.(*PanicError)
+	return ok
+}
@@ -0,0 +1,119 @@
+package parse_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+func TestSafe(t *testing.T) {
+	as := NewAssert(t)
+
+	num := parse.RegExp(`[0-9]+`)
+	bad := parse.String("x=").Then(num.Map(func(r any) any {
+		return r.(int) + 1
+	}))
+
+	as.Panics(func() {
+		_, _ = bad.Parse("x=42")
+	})
+
+	s, f := bad.Safe().Parse("x=42")
+	as.Failure(s, f)
+	var pe *parse.PanicError
+	as.True(errors.As(f.Error, &pe))
+	as.NotNil(pe.Value)
+	as.Contains(string(pe.Stack), "safe_test.go")
+	as.Contains(f.Error.Error(), "action panicked: ")
+	as.ErrorIs(f.Error, parse.ErrActionPanicked)
+	as.Equal(2, f.Input.Offset())
+
+	s, f = bad.Or(parse.String("x=").Return("recovered")).Safe().Parse("x=1")
+	as.Failure(s, f)
+	as.ErrorIs(f.Error, parse.ErrActionPanicked)
+
+	for _, p := range []parse.Parser{
+		bad.Optional(),
+		bad.ZeroOrMore(),
+		parse.Peek(bad),
+		parse.NotFollowedBy(bad),
+		bad.Recover(parse.String(";")),
+	} {
+		s, f = p.Safe().Parse("x=1;")
+		as.Failure(s, f)
+		as.ErrorIs(f.Error, parse.ErrActionPanicked)
+		as.Equal(2, f.Input.Offset())
+	}
+}
+
+func TestSafeCallbacks(t *testing.T) {
+	as := NewAssert(t)
+
+	num := parse.RegExp(`[0-9]+`)
+	boom := func(any) any {
+		panic("boom")
+	}
+	for _, p := range []parse.Parser{
+		num.Validate(func(r any) error {
+			boom(r)
+			return nil
+		}),
+		num.Where(func(r any) bool {
+			boom(r)
+			return true
+		}, "number"),
+		parse.UpdateState(boom).Then(num),
+		parse.Satisfy(func(i parse.Input) (int, error) {
+			boom(i)
+			return 0, nil
+		}),
+	} {
+		s, f := parse.String("x=").Then(p).Safe().Parse("x=1")
+		as.Failure(s, f)
+		as.ErrorIs(f.Error, parse.ErrActionPanicked)
+		as.Equal(2, f.Input.Offset())
+	}
+}
+
+func TestSafeLimits(t *testing.T) {
+	as := NewAssert(t)
+
+	p := nested().Map(func(r any) any {
+		panic("boom")
+	}).Safe()
+	s, f := p.ParseContext(context.Background(), "((x))", parse.Limits{
+		MaxDepth: 3,
+	})
+	as.Failure(s, f)
+	as.ErrorIs(f.Error, parse.ErrLimitExceeded)
+
+	s, f = p.ParseContext(context.Background(), "((x))", parse.Limits{})
+	as.Failure(s, f)
+	as.ErrorIs(f.Error, parse.ErrActionPanicked)
+}
+
+func TestSafeActions(t *testing.T) {
+	as := NewAssert(t)
+
+	boom := func() { panic("boom") }
+	for _, p := range []parse.Parser{
+		parse.String("a").Capture(func(any) { boom() }),
+		parse.String("a").Combine(func(...any) any { boom(); return nil }),
+		parse.String("a").Bind(func(any) parse.Parser { boom(); return nil }),
+	} {
+		s, f := p.Safe().Parse("a")
+		as.Failure(s, f)
+		as.Equal(&parse.PanicError{
+			Value: "boom",
+			Stack: f.Error.(*parse.PanicError).Stack,
+		}, f.Error)
+		as.EqualError(f.Error, "action panicked: boom")
+	}
+
+	s, f := parse.String("a").Map(func(r any) any {
+		return r.(string) + "b"
+	}).Safe().Parse("a")
+	as.SuccessResult(s, f, "ab")
+}
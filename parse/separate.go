@@ -0,0 +1,101 @@
+package parse
+
+// Folder combines the results of a left and right operand with the result of
+// the operator that separated them
+type Folder func(l, op, r any) any
+
+// SepBy returns a new Parser, the result of which is the Combined set of
+// values matched by the provided Parser and delimited by the provided
+// Delimiter, performed zero or more times
+func SepBy(p Parser, d Delimiter) Parser {
+	return Or(Delimited(p, d), Return(Results{}))
+}
+
+// SepEndBy returns a new Parser, the result of which is the Combined set of
+// values matched by the provided Parser and delimited by the provided
+// Delimiter, performed zero or more times. A trailing Delimiter is allowed
+// after the last value, but not in place of one
+func SepEndBy(p Parser, d Delimiter) Parser {
+	return Or(
+		Delimited(p, d).Bind(func(r any) Parser {
+			return Optional(d).Return(r)
+		}),
+		Return(Results{}),
+	)
+}
+
+// EndBy returns a new Parser, the result of which is the Combined set of
+// values matched by the provided Parser, performed zero or more times, with
+// each match followed by the provided Delimiter
+func EndBy(p Parser, d Delimiter) Parser {
+	return ZeroOrMore(p.Bind(func(r any) Parser {
+		return d.Return(r)
+	}))
+}
+
+// DelimitedKeep returns a new Parser that behaves like Delimited, except
+// that the results of the Delimiters are retained between the values
+func DelimitedKeep(p Parser, d Delimiter) Parser {
+	return Concat(p, ZeroOrMore(Concat(d, p)))
+}
+
+// SepByKeep returns a new Parser that behaves like SepBy, except that the
+// results of the Delimiters are retained between the values
+func SepByKeep(p Parser, d Delimiter) Parser {
+	return Or(DelimitedKeep(p, d), Return(Results{}))
+}
+
+// SepEndByKeep returns a new Parser that behaves like SepEndBy, except that
+// the results of the Delimiters, including any trailing one, are retained
+func SepEndByKeep(p Parser, d Delimiter) Parser {
+	return Or(
+		Concat(DelimitedKeep(p, d), Or(d, Return(Results{}))),
+		Return(Results{}),
+	)
+}
+
+// EndByKeep returns a new Parser that behaves like EndBy, except that the
+// results of the Delimiters are retained after each value
+func EndByKeep(p Parser, d Delimiter) Parser {
+	return ZeroOrMore(Concat(p, d))
+}
+
+// ChainL1 returns a new Parser that matches one or more values with the
+// provided Parser, separated by the provided operator Parser. The results
+// are folded from the left using the provided Folder, so that "1-2-3" is
+// folded as ((1-2)-3)
+func ChainL1(p Parser, op Parser, fn Folder) Parser {
+	return p.Bind(func(l any) Parser {
+		return chainL(l, p, op, fn)
+	})
+}
+
+// ChainR1 returns a new Parser that matches one or more values with the
+// provided Parser, separated by the provided operator Parser. The results
+// are folded from the right using the provided Folder, so that "2^3^4" is
+// folded as (2^(3^4))
+func ChainR1(p Parser, op Parser, fn Folder) Parser {
+	var chain Parser
+	chain = p.Bind(func(l any) Parser {
+		return Or(
+			op.Bind(func(o any) Parser {
+				return chain.Map(func(r any) any {
+					return fn(l, o, r)
+				})
+			}),
+			Return(l),
+		)
+	})
+	return chain
+}
+
+func chainL(l any, p Parser, op Parser, fn Folder) Parser {
+	return Or(
+		op.Bind(func(o any) Parser {
+			return p.Bind(func(r any) Parser {
+				return chainL(fn(l, o, r), p, op, fn)
+			})
+		}),
+		Return(l),
+	)
+}
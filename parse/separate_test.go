@@ -0,0 +1,129 @@
+package parse_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+var (
+	digit = parse.RegExp("[0-9]")
+	comma = parse.String(",")
+	semi  = parse.String(";")
+)
+
+func TestSepBy(t *testing.T) {
+	as := NewAssert(t)
+
+	list := digit.SepBy(comma)
+	s, f := list.Parse("1,2,3,")
+	as.SuccessResults(s, f, "1", "2", "3")
+	as.Equal(",", s.Remaining.String())
+
+	s, f = list.Parse("nope")
+	as.SuccessResults(s, f)
+	as.Equal("nope", s.Remaining.String())
+
+	keep := digit.SepByKeep(comma)
+	s, f = keep.Parse("1,2,3")
+	as.SuccessResults(s, f, "1", ",", "2", ",", "3")
+
+	s, f = keep.Parse("")
+	as.SuccessResults(s, f)
+}
+
+func TestSepEndBy(t *testing.T) {
+	as := NewAssert(t)
+
+	list := digit.SepEndBy(comma)
+	s, f := list.Parse("1,2,3,")
+	as.SuccessResults(s, f, "1", "2", "3")
+	as.Equal("", s.Remaining.String())
+
+	s, f = list.Parse("1,2")
+	as.SuccessResults(s, f, "1", "2")
+	as.Equal("", s.Remaining.String())
+
+	s, f = list.Parse(",")
+	as.SuccessResults(s, f)
+	as.Equal(",", s.Remaining.String())
+
+	keep := digit.SepEndByKeep(comma)
+	s, f = keep.Parse("1,2,")
+	as.SuccessResults(s, f, "1", ",", "2", ",")
+
+	s, f = keep.Parse("1,2")
+	as.SuccessResults(s, f, "1", ",", "2")
+
+	s, f = keep.Parse(",")
+	as.SuccessResults(s, f)
+	as.Equal(",", s.Remaining.String())
+}
+
+func TestEndBy(t *testing.T) {
+	as := NewAssert(t)
+
+	stmts := digit.EndBy(semi)
+	s, f := stmts.Parse("1;2;3")
+	as.SuccessResults(s, f, "1", "2")
+	as.Equal("3", s.Remaining.String())
+
+	keep := digit.EndByKeep(semi)
+	s, f = keep.Parse("1;2;")
+	as.SuccessResults(s, f, "1", ";", "2", ";")
+	as.Equal("", s.Remaining.String())
+}
+
+func TestDelimitedKeep(t *testing.T) {
+	as := NewAssert(t)
+
+	list := digit.DelimitedKeep(comma)
+	s, f := list.Parse("1,2")
+	as.SuccessResults(s, f, "1", ",", "2")
+
+	s, f = list.Parse("x")
+	as.FailureWrapped(s, f,
+		parse.ErrExpectedPattern, "[0-9]", "x",
+	)
+}
+
+func TestChainL1(t *testing.T) {
+	as := NewAssert(t)
+
+	sub := integer().ChainL1(parse.String("-"), arithmetic)
+	s, f := sub.Parse("10-4-3")
+	as.SuccessResult(s, f, 3)
+
+	s, f = sub.Parse("10-")
+	as.SuccessResult(s, f, 10)
+	as.Equal("-", s.Remaining.String())
+
+	s, f = sub.Parse("-")
+	as.Failure(s, f)
+}
+
+func TestChainR1(t *testing.T) {
+	as := NewAssert(t)
+
+	sub := integer().ChainR1(parse.String("-"), arithmetic)
+	s, f := sub.Parse("10-4-3")
+	as.SuccessResult(s, f, 9)
+
+	s, f = sub.Parse("7")
+	as.SuccessResult(s, f, 7)
+}
+
+func integer() parse.Parser {
+	return parse.RegExp("[0-9]+").Map(func(r any) any {
+		res, _ := strconv.Atoi(r.(string))
+		return res
+	})
+}
+
+func arithmetic(l, op, r any) any {
+	if op == "-" {
+		return l.(int) - r.(int)
+	}
+	return l.(int) + r.(int)
+}
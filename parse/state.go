@@ -0,0 +1,75 @@
+package parse
+
+import "reflect"
+
+// GetState is a Parser that consumes none of the Input, and produces the
+// user state that is current at its position
+var GetState = Parser(func(i Input) (*Success, *Failure) {
+	return i.succeedWith(i.state)
+})
+
+// State returns the user state that is current at the Input's position
+func (i Input) State() any {
+	return i.state
+}
+
+// WithState returns an Input at the same position, but that carries the
+// provided user state. User state should be treated as immutable, so that
+// it can be restored when a Parser backtracks
+func (i Input) WithState(s any) Input {
+	return Input{
+		source: i.source,
+		offset: i.offset,
+		state:  s,
+		indent: i.indent,
+	}
+}
+
+// SetState returns a new Parser. This Parser consumes none of the Input,
+// but replaces the user state for all Parsers that follow it. Because the
+// state travels with the Input, it is rolled back whenever a Parser
+// backtracks, such as when Or attempts its alternative. The result is
+// Skipped
+func SetState(s any) Parser {
+	return func(i Input) (*Success, *Failure) {
+		return i.WithState(s).succeedWith(Skipped)
+	}
+}
+
+// UpdateState returns a new Parser. This Parser consumes none of the Input,
+// but replaces the user state with the result of passing it to the provided
+// Mapper. The result is Skipped
+func UpdateState(fn Mapper) Parser {
+	return func(i Input) (*Success, *Failure) {
+		return i.WithState(i.mapState(fn)).succeedWith(Skipped)
+	}
+}
+
+func comparableState(s any) bool {
+	return s == nil || comparableValue(reflect.ValueOf(s))
+}
+
+// comparableValue reports whether the value can be compared, which depends
+// on what any of its interface fields hold, and not only on its type
+func comparableValue(v reflect.Value) bool {
+	if !v.Type().Comparable() {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		return v.IsNil() || comparableValue(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !comparableValue(v.Field(i)) {
+				return false
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !comparableValue(v.Index(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package parse_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+type typeNames struct {
+	name string
+	next *typeNames
+}
+
+func (t *typeNames) has(name string) bool {
+	for ; t != nil; t = t.next {
+		if t.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestState(t *testing.T) {
+	as := NewAssert(t)
+
+	s, f := parse.GetState(parse.NewInput("x").WithState(42))
+	as.Nil(f)
+	as.Equal(42, s.Result)
+	as.Equal(42, s.Remaining.State())
+
+	inc := parse.UpdateState(func(r any) any {
+		return r.(int) + 1
+	})
+	p := inc.Concat(parse.String("a")).Concat(inc).Concat(parse.GetState)
+	s, f = p(parse.NewInput("ab").WithState(1))
+	as.Nil(f)
+	as.Equal(parse.Results{"a", 3}, s.Result)
+	as.Equal(3, s.Remaining.State())
+	as.Equal("b", s.Remaining.String())
+}
+
+func TestStateBacktracking(t *testing.T) {
+	as := NewAssert(t)
+
+	p := parse.SetState("left").Then(parse.String("a")).
+		Or(parse.GetState)
+	s, f := p(parse.NewInput("b").WithState("initial"))
+	as.Nil(f)
+	as.Equal("initial", s.Result)
+
+	s, f = p(parse.NewInput("a").WithState("initial"))
+	as.Nil(f)
+	as.Equal("a", s.Result)
+	as.Equal("left", s.Remaining.State())
+
+	s, f = parse.SetState(1).Peek().Then(parse.GetState).Parse("")
+	as.Nil(f)
+	as.Equal(nil, s.Result)
+}
+
+func TestStateTypedef(t *testing.T) {
+	as := NewAssert(t)
+
+	ws := parse.RegExp(`\s*`).Skip()
+	ident := ws.Then(parse.RegExp(`[a-zA-Z]+`))
+	semi := ws.Then(parse.String(";")).Skip()
+
+	typedef := ws.Then(parse.String("typedef")).Then(ident).Then(ident).
+		Bind(func(name any) parse.Parser {
+			return parse.UpdateState(func(s any) any {
+				next, _ := s.(*typeNames)
+				return &typeNames{name: name.(string), next: next}
+			}).Return(fmt.Sprintf("typedef %s", name))
+		})
+
+	typeName := parse.GetState.Bind(func(s any) parse.Parser {
+		names, _ := s.(*typeNames)
+		return ident.Bind(func(r any) parse.Parser {
+			if names.has(r.(string)) {
+				return parse.Return(r)
+			}
+			return parse.Fail("%s is not a type", r)
+		})
+	})
+
+	decl := typeName.Concat(ident).Combine(func(r ...any) any {
+		return fmt.Sprintf("%s %s", r[1], r[0])
+	})
+
+	program := typedef.Or(decl).Left(semi).ZeroOrMore().Left(ws).
+		Left(parse.EOF)
+
+	s, f := program.Parse("typedef int T; T x; typedef T U; U y;")
+	as.Nil(f)
+	as.Equal(parse.Results{
+		"typedef T", "x T", "typedef U", "y U",
+	}, s.Result)
+
+	s, f = program.Parse("typedef int T; V x;")
+	as.Nil(s)
+	as.NotNil(f)
+
+	m := typeName.Memo()
+	s, f = m.Or(parse.SetState(&typeNames{name: "T"}).Then(m)).Parse("T")
+	as.Nil(f)
+	as.Equal("T", s.Result)
+}
+
+func TestStateIncomparable(t *testing.T) {
+	as := NewAssert(t)
+
+	type boxed struct {
+		v any
+	}
+	calls := 0
+	p := parse.Parser(func(i parse.Input) (*parse.Success, *parse.Failure) {
+		calls++
+		return parse.String("a")(i)
+	}).Memo()
+	twice := p.Then(parse.String("x")).Or(p.Then(parse.String("y")))
+
+	in := parse.NewInput("ay").WithState(boxed{[]int{1}})
+	s, f := twice(in)
+	as.SuccessResult(s, f, "y")
+	as.Equal(2, calls)
+
+	calls = 0
+	in = parse.NewInput("ay").WithState(boxed{[2]any{1, "b"}})
+	s, f = twice(in)
+	as.SuccessResult(s, f, "y")
+	as.Equal(1, calls)
+}
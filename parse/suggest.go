@@ -0,0 +1,198 @@
+package parse
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type (
+	// SuggestionError is the error of the Failure that a Suggest Parser
+	// returns when a literal that it expected closely resembles the text
+	// where it failed. Err is the error of the original Failure
+	SuggestionError struct {
+		Err     error
+		Literal string
+	}
+
+	// suggester collects the literals that were attempted at the furthest
+	// offset that any literal was attempted
+	suggester struct {
+		offset   int
+		seen     map[string]bool
+		literals []string
+	}
+)
+
+// Errors that Parsers fail with
+var (
+	ErrDidYouMean = errors.New("did you mean")
+)
+
+// Suggest returns a new Parser that matches the provided Parser. If that
+// Parser fails, the literals that it attempted to match where it failed are
+// compared to the text found there instead. If one of them is within a few
+// edits of that text, the Failure's error is a *SuggestionError that
+// suggests the closest one. Literals are collected while the provided
+// Parser is performed, so it is only performed once
+func Suggest(p Parser) Parser {
+	return func(i Input) (*Success, *Failure) {
+		if i.source == nil || i.suggest != nil {
+			return p(i)
+		}
+		s, f, sg := i.suggesting(p)
+		if f == nil {
+			return s, nil
+		}
+		if sg.offset != f.offset {
+			return nil, f
+		}
+		lit, ok := closest(f.word(), sg.literals)
+		if !ok {
+			return nil, f
+		}
+		return nil, &Failure{
+			Error: &SuggestionError{
+				Err:     f.Error,
+				Literal: lit,
+			},
+			Input:       f.Input,
+			Diagnostics: f.Diagnostics,
+		}
+	}
+}
+
+// Error describes the SuggestionError using the English Catalog
+func (e *SuggestionError) Error() string {
+	return English.Format(e)
+}
+
+// Unwrap returns the error of the original Failure
+func (e *SuggestionError) Unwrap() error {
+	return e.Err
+}
+
+func (i Input) suggesting(p Parser) (*Success, *Failure, *suggester) {
+	sg := newSuggester()
+	prev := i.suggest
+	i.suggest = sg
+	defer func() {
+		i.suggest = prev
+	}()
+	s, f := p(i)
+	return s, f, sg
+}
+
+func (i Input) attempted(lit string) {
+	if sg := i.suggest; sg != nil {
+		sg.add(i.offset, lit)
+	}
+}
+
+func newSuggester() *suggester {
+	return &suggester{
+		offset: -1,
+		seen:   map[string]bool{},
+	}
+}
+
+func (sg *suggester) add(offset int, lit string) {
+	if offset > sg.offset {
+		sg.offset = offset
+		sg.seen = map[string]bool{}
+		sg.literals = nil
+	}
+	if offset == sg.offset && !sg.seen[lit] {
+		sg.seen[lit] = true
+		sg.literals = append(sg.literals, lit)
+	}
+}
+
+func (sg *suggester) merge(other *suggester) {
+	for _, lit := range other.literals {
+		sg.add(other.offset, lit)
+	}
+}
+
+func (sg *suggester) rebase(delta int) *suggester {
+	res := *sg
+	res.offset += delta
+	return &res
+}
+
+func (i Input) word() string {
+	str := i.String()
+	if str == "" {
+		return ""
+	}
+	first, _ := utf8.DecodeRuneInString(str)
+	word := isWordRune(first)
+	end := strings.IndexFunc(str, func(r rune) bool {
+		return unicode.IsSpace(r) || isWordRune(r) != word
+	})
+	if end < 0 {
+		return str
+	}
+	return str[:end]
+}
+
+func closest(word string, literals []string) (string, bool) {
+	var res string
+	best := -1
+	for _, lit := range literals {
+		d := editDistance([]rune(word), []rune(lit))
+		if d == 0 {
+			return "", false
+		}
+		limit := utf8.RuneCountInString(lit) / 3
+		if limit < 1 {
+			limit = 1
+		}
+		if d <= limit && (best < 0 || d < best) {
+			res, best = lit, d
+		}
+	}
+	return res, best >= 0
+}
+
+// editDistance counts the insertions, deletions, substitutions, and
+// transpositions of adjacent runes needed to turn one string into another
+func editDistance(l, r []rune) int {
+	prev := make([]int, len(r)+1)
+	curr := make([]int, len(r)+1)
+	older := make([]int, len(r)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(l); i++ {
+		curr[0] = i
+		for j := 1; j <= len(r); j++ {
+			cost := 1
+			if l[i-1] == r[j-1] {
+				cost = 0
+			}
+			d := minOf(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && l[i-1] == r[j-2] && l[i-2] == r[j-1] {
+				d = minOf(d, older[j-2]+1)
+			}
+			curr[j] = d
+		}
+		older, prev, curr = prev, curr, older
+	}
+	return prev[len(r)]
+}
+
+func minOf(first int, rest ...int) int {
+	res := first
+	for _, v := range rest {
+		if v < res {
+			res = v
+		}
+	}
+	return res
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
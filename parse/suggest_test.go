@@ -0,0 +1,94 @@
+package parse_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+func keywords() parse.Parser {
+	ws := parse.RegExp(`\s*`).Skip()
+	kw := func(s string) parse.Parser {
+		return ws.Then(parse.String(s)).Left(ws)
+	}
+	stmt := parse.Any(kw("function"), kw("return"), kw("let"), kw("=>"))
+	return stmt.OneOrMore().Left(parse.EOF).Memo()
+}
+
+func TestSuggest(t *testing.T) {
+	as := NewAssert(t)
+	p := keywords().Suggest()
+
+	s, f := p.Parse("let functoin")
+	as.Failure(s, f)
+	as.EqualError(f.Error,
+		`expected end of file, got functoin (did you mean "function"?)`,
+	)
+	var se *parse.SuggestionError
+	as.True(errors.As(f.Error, &se))
+	as.Equal("function", se.Literal)
+	as.ErrorIs(f.Error, parse.ErrExpectedEndOfFile)
+	as.Equal(4, f.Input.Offset())
+
+	s, f = p.Parse("retrun")
+	as.Failure(s, f)
+	as.Equal("return", f.Error.(*parse.SuggestionError).Literal)
+
+	s, f = p.Parse("lte x")
+	as.Failure(s, f)
+	as.Equal("let", f.Error.(*parse.SuggestionError).Literal)
+
+	s, f = p.Parse("=< 1")
+	as.Failure(s, f)
+	as.Equal("=>", f.Error.(*parse.SuggestionError).Literal)
+
+	s, f = p.Parse("let fn")
+	as.Failure(s, f)
+	as.EqualError(f.Error, "expected end of file, got fn")
+
+	s, f = p.Parse("let return")
+	as.SuccessResults(s, f, "let", "return")
+}
+
+func TestSuggestCatalog(t *testing.T) {
+	as := NewAssert(t)
+
+	_, f := parse.String("select").Suggest().Parse("selcet")
+	msgs := parse.Catalog{
+		parse.ErrExpectedString: "%[1]s attendu",
+		parse.ErrDidYouMean:     "%[1]s (vouliez-vous dire %[2]s ?)",
+	}
+	as.Equal("select attendu (vouliez-vous dire select ?)", msgs.Format(f.Error))
+}
+
+func TestSuggestOnce(t *testing.T) {
+	as := NewAssert(t)
+	calls := 0
+	count := func(r any) any {
+		calls++
+		return r
+	}
+	word := parse.String("select").Or(parse.String("insert")).Map(count)
+	p := word.Left(parse.String(" ")).OneOrMore().Left(parse.EOF).Suggest()
+
+	s, f := p.Parse("select insert selcet")
+	as.Failure(s, f)
+	as.Equal("select", f.Error.(*parse.SuggestionError).Literal)
+	as.Equal(14, f.Input.Offset())
+	as.Equal(2, calls)
+
+	memo := word.Memo()
+	p = memo.Left(parse.String("!")).Or(memo.Left(parse.String("?"))).
+		Suggest()
+	calls = 0
+	s, f = p.Parse("insrt")
+	as.Failure(s, f)
+	as.Equal("insert", f.Error.(*parse.SuggestionError).Literal)
+	as.Equal(0, calls)
+
+	s, f = p.Parse("select.")
+	as.Failure(s, f)
+	as.ErrorIs(f.Error, parse.ErrExpectedString)
+	as.Equal(1, calls)
+}
@@ -1,21 +1,33 @@
 package parse
 
 import (
+	"errors"
+	"io"
 	"regexp"
 	"strings"
 )
 
-type normalizer func(string) string
+type (
+	normalizer func(string) string
 
-// Error messages
-const (
-	ErrExpectedPattern = "expected pattern: %s"
-	ErrExpectedString  = "expected string %s"
+	// runeCounter reports how far a regular expression reads into its
+	// Input, so that memoized outcomes know what text they depend on
+	runeCounter struct {
+		*strings.Reader
+		read int
+		eof  bool
+	}
+)
+
+// Errors that Parsers fail with
+var (
+	ErrExpectedPattern = errors.New("expected pattern")
+	ErrExpectedString  = errors.New("expected string")
 )
 
 // RegExp returns a Parser that is used to Satisfy an IsRegExp Predicate
 func RegExp(s string) Parser {
-	return Satisfy(IsRegExp(s)).Map(toString)
+	return Satisfy(IsRegExp(s))
 }
 
 // IsRegExp returns a Predicate that can be used to Satisfy regular expression
@@ -23,17 +35,36 @@ func RegExp(s string) Parser {
 func IsRegExp(s string) Predicate {
 	pattern := regexp.MustCompile("^(" + s + ")")
 	return func(i Input) (int, error) {
-		if sm := pattern.FindStringSubmatch(string(i)); sm != nil {
+		if i.tracking() {
+			return matchTracked(i, pattern, s)
+		}
+		if sm := pattern.FindStringSubmatch(i.String()); sm != nil {
 			matched := sm[0]
 			return len(matched), nil
 		}
-		return 0, i.errExpected(ErrExpectedPattern, s)
+		i.expectAtEnd(ExpectPattern, s)
+		return 0, i.Expected(ErrExpectedPattern, s)
+	}
+}
+
+func matchTracked(i Input, pattern *regexp.Regexp, s string) (int, error) {
+	r := &runeCounter{Reader: strings.NewReader(i.String())}
+	loc := pattern.FindReaderIndex(r)
+	if r.eof {
+		i.examine(i.offset + r.read + 1)
+	} else {
+		i.examine(i.offset + r.read)
+	}
+	if loc != nil {
+		return loc[1], nil
 	}
+	i.expectAtEnd(ExpectPattern, s)
+	return 0, i.Expected(ErrExpectedPattern, s)
 }
 
 // String returns a Parser that is used to Satisfy an IsString Predicate
 func String(s string) Parser {
-	return Satisfy(IsString(s)).Map(toString)
+	return Satisfy(IsString(s))
 }
 
 // IsString returns a Predicate that can be used to Satisfy case-sensitive
@@ -45,7 +76,7 @@ func IsString(s string) Predicate {
 // StrCaseCmp returns a Parser that is used to Satisfy an IsStrCaseCmp
 // Predicate
 func StrCaseCmp(s string) Parser {
-	return Satisfy(IsStrCaseCmp(s)).Map(toString)
+	return Satisfy(IsStrCaseCmp(s))
 }
 
 // IsStrCaseCmp returns a Predicate that can be used to Satisfy
@@ -62,16 +93,24 @@ func stringPredicate(s string, norm normalizer) Predicate {
 	n := norm(s)
 	size := len(n)
 	return func(i Input) (int, error) {
-		if len(i) >= size {
-			cmp := string(i[0:size])
+		i.examine(i.offset + size)
+		str := i.String()
+		if len(str) >= size {
+			cmp := str[0:size]
 			if n == norm(cmp) {
 				return len(cmp), nil
 			}
+		} else if i.completing() && n[:len(str)] == norm(str) {
+			i.expect(ExpectLiteral, s)
 		}
-		return 0, i.errExpected(ErrExpectedString, s)
+		i.attempted(s)
+		return 0, i.Expected(ErrExpectedString, s)
 	}
 }
 
-func toString(r any) any {
-	return string(r.(Input))
+func (r *runeCounter) ReadRune() (rune, int, error) {
+	ch, size, err := r.Reader.ReadRune()
+	r.read += size
+	r.eof = r.eof || err == io.EOF
+	return ch, size, err
 }
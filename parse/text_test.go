@@ -1,7 +1,6 @@
 package parse_test
 
 import (
-	"fmt"
 	"strconv"
 	"testing"
 
@@ -21,7 +20,7 @@ func TestRegExp(t *testing.T) {
 
 	s, f = integer.Parse("not")
 	as.FailureWrapped(s, f,
-		fmt.Sprintf(parse.ErrExpectedPattern, "[0-9]+"), "not",
+		parse.ErrExpectedPattern, "[0-9]+", "not",
 	)
 }
 
@@ -34,7 +33,7 @@ func TestString(t *testing.T) {
 
 	s, f = strCmp.Parse("CaSe SeNsItIve")
 	as.FailureWrapped(s, f,
-		fmt.Sprintf(parse.ErrExpectedString, "Case Sensitive"),
+		parse.ErrExpectedString, "Case Sensitive",
 		"CaSe SeNsItIve",
 	)
 }
@@ -48,7 +47,7 @@ func TestStrCaseCmp(t *testing.T) {
 
 	s, f = insCmp.Parse("Ca$e INSENSITIVE")
 	as.FailureWrapped(s, f,
-		fmt.Sprintf(parse.ErrExpectedString, "Case Insensitive"),
+		parse.ErrExpectedString, "Case Insensitive",
 		"Ca$e INSENSITIVE",
 	)
 }
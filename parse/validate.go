@@ -0,0 +1,43 @@
+package parse
+
+type (
+	// Validator checks a result, returning an error if it is invalid
+	Validator func(any) error
+
+	// Condition reports whether a result is acceptable
+	Condition func(any) bool
+)
+
+// Validate returns a new Parser that matches the provided Parser, and then
+// checks its result with the provided Validator. If the Validator returns
+// an error, the Parser fails with that error, positioned at the start of
+// the provided Parser's match rather than where the match ended
+func Validate(p Parser, v Validator) Parser {
+	return func(i Input) (*Success, *Failure) {
+		s, f := p(i)
+		if f != nil {
+			return nil, f
+		}
+		if err := i.validate(v, s.Result); err != nil {
+			return i.failWith(err)
+		}
+		return s, nil
+	}
+}
+
+// Where returns a new Parser that matches the provided Parser only if its
+// result satisfies the provided Condition. Otherwise, the Parser fails at
+// the start of the provided Parser's match, reporting that the label was
+// expected
+func Where(p Parser, c Condition, label string) Parser {
+	return func(i Input) (*Success, *Failure) {
+		s, f := p(i)
+		if f != nil {
+			return nil, f
+		}
+		if !i.satisfies(c, s.Result) {
+			return i.failError(ErrExpectedLabel, label)
+		}
+		return s, nil
+	}
+}
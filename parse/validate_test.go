@@ -0,0 +1,65 @@
+package parse_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+var errOutOfRange = errors.New("out of range")
+
+func byteValue() parse.Parser {
+	return parse.RegExp(`[0-9]+`).Map(func(r any) any {
+		n, _ := strconv.Atoi(r.(string))
+		return n
+	})
+}
+
+func TestValidate(t *testing.T) {
+	as := NewAssert(t)
+
+	octet := byteValue().Validate(func(r any) error {
+		if r.(int) > 255 {
+			return fmt.Errorf("%d is %w", r, errOutOfRange)
+		}
+		return nil
+	})
+	ip := octet.Then(parse.String(".")).Then(octet)
+
+	s, f := ip.Parse("10.255")
+	as.SuccessResult(s, f, 255)
+
+	s, f = ip.Parse("10.256")
+	as.FailureError(s, f, "256 is out of range")
+	as.True(errors.Is(f.Error, errOutOfRange))
+	as.Equal(3, f.Input.Offset())
+	as.Equal("256", f.Input.String())
+
+	s, f = ip.Parse("10.x")
+	as.FailureWrapped(s, f,
+		parse.ErrExpectedPattern, "[0-9]+", "x",
+	)
+}
+
+func TestWhere(t *testing.T) {
+	as := NewAssert(t)
+
+	even := byteValue().Where(func(r any) bool {
+		return r.(int)%2 == 0
+	}, "even number")
+	p := parse.String("n=").Then(even)
+
+	s, f := p.Parse("n=42")
+	as.SuccessResult(s, f, 42)
+
+	s, f = p.Parse("n=41")
+	as.FailureError(s, f, "expected even number, got 41")
+	as.ErrorIs(f.Error, parse.ErrExpectedLabel)
+	as.Equal(2, f.Input.Offset())
+
+	s, f = even.Or(parse.RegExp(`[0-9]+`)).Parse("7")
+	as.SuccessResult(s, f, "7")
+}
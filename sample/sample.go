@@ -0,0 +1,456 @@
+// Package sample produces random sentences from a Grammar. Sentences are
+// built by walking the Grammar's Rules, choosing among alternatives and
+// repetitions at random, and are checked against a Parser so that only
+// valid sentences are produced. Near-miss mutations of valid sentences can
+// also be produced. Both are suited to seeding fuzz tests and to checking
+// that printers round-trip what they print
+package sample
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/kode4food/kombi/grammar"
+	"github.com/kode4food/kombi/parse"
+)
+
+type (
+	// Options configure a Generator
+	Options struct {
+		// Start names the Rule that sentences are produced from. It
+		// defaults to the Grammar's starting Rule
+		Start string
+
+		// Parser checks the validity of sentences, which must be matched
+		// in their entirety. It defaults to the Grammar's Start Rule,
+		// compiled without trivia
+		Parser parse.Parser
+
+		// MaxDepth bounds how deeply Rules are nested within a sentence.
+		// Beyond it, the alternatives and repetitions that end a sentence
+		// soonest are chosen. It defaults to 8
+		MaxDepth int
+
+		// MaxRepeat is the greatest number of times that a repetition is
+		// performed. It defaults to 3
+		MaxRepeat int
+
+		// MaxAttempts is the number of sentences or mutations that will be
+		// attempted before giving up on producing a valid sentence or an
+		// invalid mutation. It defaults to 100
+		MaxAttempts int
+
+		// Weights biases the choice among alternatives. Alternatives are
+		// keyed by their String representation, and those that are
+		// missing have a weight of 1
+		Weights map[string]float64
+
+		// Rand is the source of randomness. It defaults to one that is
+		// seeded by the current time
+		Rand *rand.Rand
+	}
+
+	// Corpus accepts seed inputs for a fuzz test. A *testing.F is a Corpus
+	Corpus interface {
+		Add(args ...any)
+	}
+
+	// Generator produces random sentences from a Grammar
+	Generator struct {
+		Options
+		grammar *grammar.Grammar
+		heights map[string]int
+		classes map[string][]rune
+	}
+
+	walker struct {
+		*Generator
+		buf   strings.Builder
+		depth int
+	}
+)
+
+// Error messages
+const (
+	ErrNoSentence   = "no valid sentence produced after %d attempts"
+	ErrNoMutation   = "no invalid mutation produced after %d attempts"
+	ErrUnproductive = "rule %s cannot produce a finite sentence"
+	ErrEmptyClass   = "character class %s matches no characters"
+)
+
+const (
+	defaultMaxDepth    = 8
+	defaultMaxRepeat   = 3
+	defaultMaxAttempts = 100
+
+	unbounded  = math.MaxInt32
+	maxPrinted = 0x7e
+)
+
+// New returns a Generator that produces sentences from the provided Grammar
+func New(g *grammar.Grammar, o Options) (*Generator, error) {
+	if o.Start == "" {
+		o.Start = g.Start()
+	}
+	if _, ok := g.Rule(o.Start); !ok {
+		return nil, fmt.Errorf(grammar.ErrUndefinedRule, o.Start)
+	}
+	if o.Parser == nil {
+		p, err := g.CompileCST(nil)
+		if err != nil {
+			return nil, err
+		}
+		o.Parser = p[o.Start]
+	}
+	if o.MaxDepth <= 0 {
+		o.MaxDepth = defaultMaxDepth
+	}
+	if o.MaxRepeat <= 0 {
+		o.MaxRepeat = defaultMaxRepeat
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = defaultMaxAttempts
+	}
+	if o.Rand == nil {
+		o.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	res := &Generator{
+		Options: o,
+		grammar: g,
+		classes: map[string][]rune{},
+	}
+	res.measure()
+	if res.heights[o.Start] == unbounded {
+		return nil, fmt.Errorf(ErrUnproductive, o.Start)
+	}
+	return res, nil
+}
+
+// Sentence returns a random sentence that the Grammar matches
+func (g *Generator) Sentence() (string, error) {
+	for n := 0; n < g.MaxAttempts; n++ {
+		s, err := g.produce()
+		if err != nil {
+			return "", err
+		}
+		if g.valid(s) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf(ErrNoSentence, g.MaxAttempts)
+}
+
+// Mutation returns a random sentence that the Grammar doesn't match, but
+// that differs from one that it does by a single small edit
+func (g *Generator) Mutation() (string, error) {
+	for n := 0; n < g.MaxAttempts; n++ {
+		s, err := g.Sentence()
+		if err != nil {
+			return "", err
+		}
+		if m := g.mutate(s); !g.valid(m) {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf(ErrNoMutation, g.MaxAttempts)
+}
+
+// Seed adds n random sentences to the provided Corpus
+func (g *Generator) Seed(c Corpus, n int) error {
+	for ; n > 0; n-- {
+		s, err := g.Sentence()
+		if err != nil {
+			return err
+		}
+		c.Add(s)
+	}
+	return nil
+}
+
+func (g *Generator) produce() (string, error) {
+	w := &walker{Generator: g}
+	if err := w.ref(g.Start); err != nil {
+		return "", err
+	}
+	return w.buf.String(), nil
+}
+
+func (g *Generator) valid(s string) bool {
+	res, f := g.Parser.Parse(s)
+	return f == nil && res.Remaining.Len() == 0
+}
+
+func (g *Generator) mutate(s string) string {
+	r := []rune(s)
+	pos := g.Rand.Intn(len(r) + 1)
+	switch op := g.Rand.Intn(4); {
+	case op == 0 && pos < len(r):
+		return string(r[:pos]) + string(r[pos+1:])
+	case op == 1 && pos+1 < len(r):
+		r[pos], r[pos+1] = r[pos+1], r[pos]
+		return string(r)
+	case op == 2 && pos < len(r):
+		return string(r[:pos]) + string(r[pos:pos+1]) + string(r[pos:])
+	default:
+		return string(r[:pos]) + string(g.printable()) + string(r[pos:])
+	}
+}
+
+// measure finds, for every Rule, the fewest nested Rules that it must
+// expand in order to produce a sentence
+func (g *Generator) measure() {
+	g.heights = make(map[string]int, len(g.grammar.Rules))
+	for _, r := range g.grammar.Rules {
+		g.heights[r.Name] = unbounded
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, r := range g.grammar.Rules {
+			if h := g.height(r.Expr); h < g.heights[r.Name] {
+				g.heights[r.Name] = h
+				changed = true
+			}
+		}
+	}
+}
+
+func (g *Generator) height(e grammar.Expr) int {
+	switch e := e.(type) {
+	case grammar.Choice:
+		res := unbounded
+		for _, a := range e {
+			if h := g.height(a); h < res {
+				res = h
+			}
+		}
+		return res
+	case grammar.Sequence:
+		res := 0
+		for _, s := range e {
+			if h := g.height(s); h > res {
+				res = h
+			}
+		}
+		return res
+	case *grammar.Action:
+		return g.height(e.Expr)
+	case *grammar.OneOrMore:
+		return g.height(e.Expr)
+	case *grammar.Ref:
+		if h, ok := g.heights[e.Name]; ok && h != unbounded {
+			return h + 1
+		}
+		return unbounded
+	default:
+		return 0
+	}
+}
+
+func (g *Generator) weight(e grammar.Expr) float64 {
+	if w, ok := g.Weights[e.String()]; ok {
+		return w
+	}
+	return 1
+}
+
+func (g *Generator) printable() rune {
+	return rune(' ' + g.Rand.Intn(maxPrinted-' '+1))
+}
+
+func (g *Generator) class(e *grammar.Class) ([]rune, error) {
+	pattern := e.Pattern
+	if e.IgnoreCase {
+		pattern = "(?i:" + pattern + ")"
+	}
+	if res, ok := g.classes[pattern]; ok {
+		return res, nil
+	}
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	res := classRanges(re.Simplify())
+	if len(res) == 0 {
+		return nil, fmt.Errorf(ErrEmptyClass, e.Pattern)
+	}
+	g.classes[pattern] = res
+	return res, nil
+}
+
+func (w *walker) expr(e grammar.Expr) error {
+	switch e := e.(type) {
+	case grammar.Choice:
+		return w.expr(w.choose(e))
+	case grammar.Sequence:
+		for _, s := range e {
+			if err := w.expr(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *grammar.Action:
+		return w.expr(e.Expr)
+	case *grammar.ZeroOrMore:
+		return w.repeat(e.Expr, 0)
+	case *grammar.OneOrMore:
+		return w.repeat(e.Expr, 1)
+	case *grammar.Optional:
+		return w.repeat(e.Expr, 0, 1)
+	case *grammar.Ref:
+		return w.ref(e.Name)
+	case *grammar.Literal:
+		w.literal(e)
+		return nil
+	case *grammar.Class:
+		return w.class(e)
+	case *grammar.AnyChar:
+		w.buf.WriteRune(w.printable())
+		return nil
+	case *grammar.And, *grammar.Not:
+		return nil
+	default:
+		return fmt.Errorf(grammar.ErrUnknownExpr, e)
+	}
+}
+
+func (w *walker) ref(name string) error {
+	r, ok := w.grammar.Rule(name)
+	if !ok {
+		return fmt.Errorf(grammar.ErrUndefinedRule, name)
+	}
+	if w.heights[name] == unbounded {
+		return fmt.Errorf(ErrUnproductive, name)
+	}
+	w.depth++
+	defer func() {
+		w.depth--
+	}()
+	return w.expr(r.Expr)
+}
+
+func (w *walker) choose(c grammar.Choice) grammar.Expr {
+	if w.exhausted() {
+		return w.shortest(c)
+	}
+	total := 0.0
+	for _, a := range c {
+		if w.height(a) != unbounded {
+			total += w.weight(a)
+		}
+	}
+	pick := w.Rand.Float64() * total
+	for _, a := range c {
+		if w.height(a) == unbounded {
+			continue
+		}
+		if pick -= w.weight(a); pick < 0 {
+			return a
+		}
+	}
+	return w.shortest(c)
+}
+
+func (w *walker) shortest(c grammar.Choice) grammar.Expr {
+	res := c[0]
+	best := w.height(res)
+	for _, a := range c[1:] {
+		if h := w.height(a); h < best {
+			res, best = a, h
+		}
+	}
+	return res
+}
+
+func (w *walker) repeat(e grammar.Expr, min int, max ...int) error {
+	n := min
+	if !w.exhausted() {
+		limit := w.MaxRepeat
+		if len(max) > 0 {
+			limit = max[0]
+		}
+		n += w.Rand.Intn(limit - min + 1)
+	}
+	for ; n > 0; n-- {
+		if err := w.expr(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) literal(e *grammar.Literal) {
+	if !e.IgnoreCase {
+		w.buf.WriteString(e.Value)
+		return
+	}
+	for _, r := range e.Value {
+		if w.Rand.Intn(2) == 0 {
+			r = unicode.SimpleFold(r)
+		}
+		w.buf.WriteRune(r)
+	}
+}
+
+func (w *walker) class(e *grammar.Class) error {
+	runes, err := w.Generator.class(e)
+	if err != nil {
+		return err
+	}
+	w.buf.WriteRune(runes[w.Rand.Intn(len(runes))])
+	return nil
+}
+
+func (w *walker) exhausted() bool {
+	return w.depth >= w.MaxDepth
+}
+
+// classRanges returns the printable ASCII characters that a character
+// class matches, or the first character of each of its ranges if it
+// matches none of them
+func classRanges(re *syntax.Regexp) []rune {
+	var ranges []rune
+	switch re.Op {
+	case syntax.OpCharClass:
+		ranges = re.Rune
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			ranges = append(ranges, r, r)
+		}
+		if re.Flags&syntax.FoldCase != 0 {
+			for _, r := range re.Rune {
+				f := unicode.SimpleFold(r)
+				ranges = append(ranges, f, f)
+			}
+		}
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		ranges = []rune{' ', maxPrinted}
+	default:
+		return nil
+	}
+	var res, firsts []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		firsts = append(firsts, lo)
+		for r := maxRune(lo, '\t'); r <= hi && r <= maxPrinted; r++ {
+			if unicode.IsPrint(r) || unicode.IsSpace(r) {
+				res = append(res, r)
+			}
+		}
+	}
+	if len(res) > 0 {
+		return res
+	}
+	return firsts
+}
+
+func maxRune(l, r rune) rune {
+	if l > r {
+		return l
+	}
+	return r
+}
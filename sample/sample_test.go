@@ -0,0 +1,218 @@
+package sample_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/kode4food/kombi/grammar"
+	"github.com/kode4food/kombi/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+const calc = `
+	Sum     <- Product (AddOp Product)*
+	Product <- Value (MulOp Value)*
+	Value   <- Number / "(" _ Sum ")" _
+	Number  <- [0-9]+ _
+	AddOp   <- [+-] _
+	MulOp   <- [*/] _
+	_       <- [ \t]*
+`
+
+func makeGenerator(
+	t *testing.T, src string, o sample.Options,
+) *sample.Generator {
+	as := assert.New(t)
+	g, err := grammar.Parse(src)
+	if !as.NoError(err) {
+		t.FailNow()
+	}
+	if o.Rand == nil {
+		o.Rand = rand.New(rand.NewSource(1))
+	}
+	res, err := sample.New(g, o)
+	if !as.NoError(err) {
+		t.FailNow()
+	}
+	return res
+}
+
+func TestSentence(t *testing.T) {
+	as := assert.New(t)
+	g, err := grammar.Parse(calc)
+	as.NoError(err)
+	p, err := g.CompileCST(nil)
+	as.NoError(err)
+
+	gen := makeGenerator(t, calc, sample.Options{})
+	for n := 0; n < 50; n++ {
+		s, err := gen.Sentence()
+		as.NoError(err)
+		res, f := p["Sum"].Parse(s)
+		if as.Nil(f, s) {
+			as.Equal(0, res.Remaining.Len(), s)
+		}
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	as := assert.New(t)
+	gen := makeGenerator(t, calc, sample.Options{
+		MaxDepth: 3,
+		Weights: map[string]float64{
+			`"(" _ Sum ")" _`: 100,
+		},
+	})
+	for n := 0; n < 20; n++ {
+		s, err := gen.Sentence()
+		as.NoError(err)
+		as.NotContains(s, "(")
+	}
+}
+
+func TestWeights(t *testing.T) {
+	as := assert.New(t)
+	src := `
+		Word <- "yes" / "no"
+	`
+	gen := makeGenerator(t, src, sample.Options{
+		Weights: map[string]float64{`"no"`: 0},
+	})
+	for n := 0; n < 20; n++ {
+		s, err := gen.Sentence()
+		as.NoError(err)
+		as.Equal("yes", s)
+	}
+}
+
+func TestStart(t *testing.T) {
+	as := assert.New(t)
+	gen := makeGenerator(t, calc, sample.Options{Start: "AddOp"})
+	s, err := gen.Sentence()
+	as.NoError(err)
+	as.Contains([]string{"+", "-"}, strings.TrimRight(s, " \t"))
+
+	g, err := grammar.Parse(calc)
+	as.NoError(err)
+	_, err = sample.New(g, sample.Options{Start: "Missing"})
+	as.EqualError(err, "rule Missing is not defined")
+}
+
+func TestIgnoreCase(t *testing.T) {
+	as := assert.New(t)
+	src := `
+		Keyword <- "select"i [a-c]i
+	`
+	gen := makeGenerator(t, src, sample.Options{})
+	seen := map[string]bool{}
+	for n := 0; n < 50; n++ {
+		s, err := gen.Sentence()
+		as.NoError(err)
+		as.Regexp(`^(?i:select[a-c])$`, s)
+		seen[s] = true
+	}
+	as.Greater(len(seen), 1)
+}
+
+func TestLookahead(t *testing.T) {
+	as := assert.New(t)
+	src := `
+		Ident <- !"if" [a-z] [a-z]?
+	`
+	gen := makeGenerator(t, src, sample.Options{})
+	for n := 0; n < 50; n++ {
+		s, err := gen.Sentence()
+		as.NoError(err)
+		as.NotEqual("if", s)
+	}
+}
+
+func TestUnproductive(t *testing.T) {
+	as := assert.New(t)
+	g, err := grammar.Parse(`
+		Loop <- "(" Loop ")"
+	`)
+	as.NoError(err)
+	_, err = sample.New(g, sample.Options{})
+	as.EqualError(err, "rule Loop cannot produce a finite sentence")
+}
+
+func TestNoSentence(t *testing.T) {
+	as := assert.New(t)
+	gen := makeGenerator(t, `
+		Never <- !"a" "a"
+	`, sample.Options{MaxAttempts: 5})
+	_, err := gen.Sentence()
+	as.EqualError(err, "no valid sentence produced after 5 attempts")
+}
+
+func TestMutation(t *testing.T) {
+	as := assert.New(t)
+	g, err := grammar.Parse(calc)
+	as.NoError(err)
+	p, err := g.CompileCST(nil)
+	as.NoError(err)
+
+	gen := makeGenerator(t, calc, sample.Options{})
+	for n := 0; n < 20; n++ {
+		s, err := gen.Mutation()
+		as.NoError(err)
+		res, f := p["Sum"].Parse(s)
+		as.True(f != nil || res.Remaining.Len() > 0, s)
+	}
+
+	gen = makeGenerator(t, `
+		Any <- .*
+	`, sample.Options{MaxAttempts: 5})
+	_, err = gen.Mutation()
+	as.EqualError(err, "no invalid mutation produced after 5 attempts")
+}
+
+type corpus []string
+
+func (c *corpus) Add(args ...any) {
+	*c = append(*c, args[0].(string))
+}
+
+func TestSeed(t *testing.T) {
+	as := assert.New(t)
+	g, err := grammar.Parse(calc)
+	as.NoError(err)
+	p, err := g.CompileCST(nil)
+	as.NoError(err)
+
+	gen := makeGenerator(t, calc, sample.Options{})
+	var c corpus
+	as.NoError(gen.Seed(&c, 5))
+	as.Len(c, 5)
+	for _, s := range c {
+		res, f := p["Sum"].Parse(s)
+		if as.Nil(f, s) {
+			as.Equal(0, res.Remaining.Len(), s)
+		}
+	}
+}
+
+func FuzzSeed(f *testing.F) {
+	g, err := grammar.Parse(calc)
+	if err != nil {
+		f.Fatal(err)
+	}
+	gen, err := sample.New(g, sample.Options{
+		Rand: rand.New(rand.NewSource(1)),
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+	if err := gen.Seed(f, 10); err != nil {
+		f.Fatal(err)
+	}
+	p, err := g.CompileCST(nil)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		p["Sum"].Parse(s)
+	})
+}
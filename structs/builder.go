@@ -0,0 +1,398 @@
+// Package structs builds Parsers from Go struct types whose fields are
+// tagged with grammar expressions, in the spirit of participle. Each tagged
+// field contributes its expression to the grammar of the struct, and the
+// values that the expression captures are stored in that field
+package structs
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+type (
+	// Builder turns tagged struct types into Parsers. It maps the token
+	// names that appear in tags to the Parsers that match them, and elides
+	// insignificant text, such as whitespace, before every token
+	Builder struct {
+		tokens map[string]parse.Parser
+		elide  parse.Parser
+	}
+
+	compiler struct {
+		*Builder
+		parsers map[reflect.Type]parse.Parser
+	}
+
+	field struct {
+		reflect.StructField
+		owner reflect.Type
+	}
+
+	captured struct {
+		index  int
+		values []any
+	}
+)
+
+// Error messages
+const (
+	ErrNotStruct     = "%s is not a struct type"
+	ErrNoFields      = "%s has no tagged fields"
+	ErrInvalidTag    = "invalid parse tag on field %s of %s: %s"
+	ErrUnknownToken  = "token %s is not registered"
+	ErrCannotRecurse = "field %s of %s cannot capture a struct"
+	ErrCannotCapture = "cannot capture %v into field %s of %s"
+	ErrUnexported    = "tagged field %s of %s is not exported"
+)
+
+const tagName = "parse"
+
+// NewBuilder returns a new Builder that elides whitespace and recognizes the
+// Ident, Int, Float, and String tokens
+func NewBuilder() *Builder {
+	return &Builder{
+		tokens: map[string]parse.Parser{
+			"Ident":  parse.RegExp(`[\pL_][\pL\pN_]*`),
+			"Int":    parse.RegExp(`[0-9]+`),
+			"Float":  parse.RegExp(`([0-9]+\.?[0-9]*|\.[0-9]+)([eE][-+]?[0-9]+)?`),
+			"String": parse.RegExp(`"(\\.|[^"\\])*"`),
+		},
+		elide: parse.RegExp(`\s*`),
+	}
+}
+
+// Token registers a Parser under the provided token name, replacing any
+// token that was previously registered with that name
+func (b *Builder) Token(name string, p parse.Parser) *Builder {
+	b.tokens[name] = p
+	return b
+}
+
+// Elide sets the Parser that matches the insignificant text preceding every
+// token and literal. A nil Parser disables elision
+func (b *Builder) Elide(p parse.Parser) *Builder {
+	b.elide = p
+	return b
+}
+
+// Build returns a Parser that matches the grammar declared by the tagged
+// fields of T, and produces a populated *T. A field's tag is a sequence of
+// 'literals' and Token names, grouped with parentheses, separated by '|',
+// and repeated with '*', '+', or '?'. Prefixing a literal, Token, or group
+// with '@' captures its matched text into the field, while '@@' captures a
+// nested struct parsed according to the field's own type. A tag that begins
+// with '|' starts an alternative to the fields that precede it. Tagged
+// fields must be exported. Text that is captured into an integer field is
+// interpreted as a decimal number. The returned Parser panics if captured
+// text can't be stored in its field, because that reveals a mistake in the
+// field's tag or type, not in the text that was matched
+func Build[T any](b *Builder) (parse.Parser, error) {
+	c := &compiler{
+		Builder: b,
+		parsers: map[reflect.Type]parse.Parser{},
+	}
+	p, err := c.structParser(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	if b.elide != nil {
+		return p.Left(b.elide), nil
+	}
+	return p, nil
+}
+
+func (c *compiler) structParser(t reflect.Type) (parse.Parser, error) {
+	if p, ok := c.parsers[t]; ok {
+		return p, nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf(ErrNotStruct, t)
+	}
+
+	var p parse.Parser
+	c.parsers[t] = func(i parse.Input) (*parse.Success, *parse.Failure) {
+		return p(i)
+	}
+
+	var alts []parse.Parser
+	var seq parse.Parser
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup(tagName)
+		if !ok {
+			continue
+		}
+		if !sf.IsExported() {
+			return nil, fmt.Errorf(ErrUnexported, sf.Name, t)
+		}
+		if alt := strings.TrimSpace(tag); strings.HasPrefix(alt, "|") {
+			if seq != nil {
+				alts = append(alts, seq)
+			}
+			seq = nil
+			tag = alt[1:]
+		}
+		f := &field{StructField: sf, owner: t}
+		n, err := parseTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf(ErrInvalidTag, sf.Name, t, err)
+		}
+		fp, err := c.compile(n, f, false)
+		if err != nil {
+			return nil, err
+		}
+		if seq == nil {
+			seq = fp
+		} else {
+			seq = seq.Concat(fp)
+		}
+	}
+	if seq != nil {
+		alts = append(alts, seq)
+	}
+	if len(alts) == 0 {
+		return nil, fmt.Errorf(ErrNoFields, t)
+	}
+
+	body := parse.Any(alts[0], alts[1:]...)
+	p = func(i parse.Input) (*parse.Success, *parse.Failure) {
+		s, f := body(i)
+		if f != nil {
+			return nil, f
+		}
+		v := reflect.New(t)
+		if err := populate(v.Elem(), s.Result); err != nil {
+			panic(err)
+		}
+		return &parse.Success{
+			Result:      v.Interface(),
+			Remaining:   s.Remaining,
+			Diagnostics: s.Diagnostics,
+		}, nil
+	}
+	return c.parsers[t], nil
+}
+
+func (c *compiler) compile(n node, f *field, capturing bool) (parse.Parser, error) {
+	switch n := n.(type) {
+	case *literal:
+		return c.terminal(literalParser(n.value), capturing), nil
+	case *token:
+		p, ok := c.tokens[n.name]
+		if !ok {
+			return nil, fmt.Errorf(ErrUnknownToken, n.name)
+		}
+		return c.terminal(p, capturing), nil
+	case *self:
+		t, ok := structType(f.Type)
+		if !ok {
+			return nil, fmt.Errorf(ErrCannotRecurse, f.Name, f.owner)
+		}
+		return c.structParser(t)
+	case *capture:
+		p, err := c.compile(n.node, f, true)
+		if err != nil || capturing {
+			return p, err
+		}
+		return p.Map(func(r any) any {
+			return &captured{
+				index:  f.Index[0],
+				values: flatten(r),
+			}
+		}), nil
+	case sequence:
+		var res parse.Parser
+		for _, e := range n {
+			p, err := c.compile(e, f, capturing)
+			if err != nil {
+				return nil, err
+			}
+			if res == nil {
+				res = p
+			} else {
+				res = res.Concat(p)
+			}
+		}
+		return res, nil
+	case choice:
+		alts := make([]parse.Parser, len(n))
+		for i, e := range n {
+			p, err := c.compile(e, f, capturing)
+			if err != nil {
+				return nil, err
+			}
+			alts[i] = p
+		}
+		return parse.Any(alts[0], alts[1:]...), nil
+	case *repeat:
+		p, err := c.compile(n.node, f, capturing)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "*":
+			return p.ZeroOrMore(), nil
+		case "+":
+			return p.OneOrMore(), nil
+		default:
+			return p.Optional(), nil
+		}
+	default:
+		panic(fmt.Sprintf("unexpected tag node: %T", n))
+	}
+}
+
+func (c *compiler) terminal(p parse.Parser, capturing bool) parse.Parser {
+	if c.elide != nil {
+		p = c.elide.Then(p)
+	}
+	if capturing {
+		return p
+	}
+	return p.Skip()
+}
+
+func literalParser(s string) parse.Parser {
+	p := parse.String(s)
+	if r, _ := utf8.DecodeLastRuneInString(s); isWordRune(r) {
+		return p.NotBefore(parse.RegExp(`[\pL\pN_]`))
+	}
+	return p
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func structType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct
+}
+
+func flatten(r any) []any {
+	switch r := r.(type) {
+	case parse.Results:
+		res := make([]any, 0, len(r))
+		for _, e := range r {
+			if e != nil && e != parse.Skipped {
+				res = append(res, e)
+			}
+		}
+		return res
+	case nil:
+		return nil
+	default:
+		if r == parse.Skipped {
+			return nil
+		}
+		return []any{r}
+	}
+}
+
+func populate(v reflect.Value, r any) error {
+	results, ok := r.(parse.Results)
+	if !ok {
+		results = parse.Results{r}
+	}
+	for _, e := range results {
+		c, ok := e.(*captured)
+		if !ok {
+			continue
+		}
+		fv := v.Field(c.index)
+		for _, val := range c.values {
+			if !store(fv, val) {
+				return fmt.Errorf(
+					ErrCannotCapture, val, v.Type().Field(c.index).Name, v.Type(),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func store(f reflect.Value, v any) bool {
+	switch f.Kind() {
+	case reflect.Slice:
+		e := reflect.New(f.Type().Elem()).Elem()
+		if !assign(e, v) {
+			return false
+		}
+		f.Set(reflect.Append(f, e))
+		return true
+	case reflect.String:
+		s, ok := v.(string)
+		if ok {
+			f.SetString(f.String() + s)
+		}
+		return ok
+	default:
+		return assign(f, v)
+	}
+}
+
+func assign(f reflect.Value, v any) bool {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Type().AssignableTo(f.Type()):
+		f.Set(rv)
+		return true
+	case rv.Kind() == reflect.Pointer && rv.Elem().Type() == f.Type():
+		f.Set(rv.Elem())
+		return true
+	case f.Kind() == reflect.Pointer:
+		e := reflect.New(f.Type().Elem())
+		if !assign(e.Elem(), v) {
+			return false
+		}
+		f.Set(e)
+		return true
+	}
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	return assignText(f, s)
+}
+
+func assignText(f reflect.Value, s string) bool {
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(s)
+	case reflect.Bool:
+		f.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, f.Type().Bits())
+		if err != nil {
+			return false
+		}
+		f.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, f.Type().Bits())
+		if err != nil {
+			return false
+		}
+		f.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, f.Type().Bits())
+		if err != nil {
+			return false
+		}
+		f.SetFloat(n)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,190 @@
+package structs_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/kombi/parse"
+	"github.com/kode4food/kombi/structs"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	program struct {
+		Stmts []*stmt `parse:"@@*"`
+	}
+
+	stmt struct {
+		Export bool  `parse:"@'export'?"`
+		Let    *let  `parse:"@@"`
+		Print  *expr `parse:"| 'print' @@ ';'"`
+	}
+
+	let struct {
+		Name  string `parse:"'let' @Ident '='"`
+		Value *expr  `parse:"@@ ';'"`
+	}
+
+	expr struct {
+		Term *term    `parse:"@@"`
+		Ops  []string `parse:"(@('+' | '-') @Int)*"`
+	}
+
+	term struct {
+		Number *int   `parse:"@Int"`
+		Call   *call  `parse:"| @@"`
+		Name   string `parse:"| @Ident"`
+		Group  *expr  `parse:"| '(' @@ ')'"`
+	}
+
+	call struct {
+		Func string  `parse:"@Ident '('"`
+		Args []*expr `parse:"(@@ (',' @@)*)? ')'"`
+	}
+)
+
+func TestBuild(t *testing.T) {
+	as := assert.New(t)
+	p, err := structs.Build[program](structs.NewBuilder())
+	as.NoError(err)
+
+	s, f := p.Left(parse.EOF).Parse(`
+		export let x = 42;
+		let lettuce = (y) + 1 - 2;
+		print f(x, g());
+	`)
+	as.Nil(f)
+	prog := s.Result.(*program)
+	as.Equal(3, len(prog.Stmts))
+
+	s0 := prog.Stmts[0]
+	as.True(s0.Export)
+	as.Equal("x", s0.Let.Name)
+	as.Equal(42, *s0.Let.Value.Term.Number)
+
+	s1 := prog.Stmts[1]
+	as.False(s1.Export)
+	as.Equal("lettuce", s1.Let.Name)
+	as.Equal("y", s1.Let.Value.Term.Group.Term.Name)
+	as.Equal([]string{"+", "1", "-", "2"}, s1.Let.Value.Ops)
+
+	s2 := prog.Stmts[2]
+	as.Nil(s2.Let)
+	as.Equal("f", s2.Print.Term.Call.Func)
+	as.Equal(2, len(s2.Print.Term.Call.Args))
+	as.Equal("x", s2.Print.Term.Call.Args[0].Term.Name)
+	as.Equal("g", s2.Print.Term.Call.Args[1].Term.Call.Func)
+	as.Nil(s2.Print.Term.Call.Args[1].Term.Call.Args)
+}
+
+func TestBuildCaptureText(t *testing.T) {
+	as := assert.New(t)
+
+	type version struct {
+		Text  string  `parse:"@(Int '.' Int)"`
+		Parts []uint8 `parse:"'(' @Int (',' @Int)* ')'"`
+		Ratio float64 `parse:"@Float"`
+	}
+
+	p, err := structs.Build[version](structs.NewBuilder())
+	as.NoError(err)
+
+	s, f := p.Parse("1 . 25 (3, 4) 0.5")
+	as.Nil(f)
+	v := s.Result.(*version)
+	as.Equal("1.25", v.Text)
+	as.Equal([]uint8{3, 4}, v.Parts)
+	as.Equal(0.5, v.Ratio)
+
+	s, f = p.Parse("1.2 (010, 08) 1")
+	as.Nil(f)
+	as.Equal([]uint8{10, 8}, s.Result.(*version).Parts)
+
+	as.PanicsWithError(fmt.Sprintf(structs.ErrCannotCapture,
+		"300", "Parts", "structs_test.version",
+	), func() {
+		p.Optional().Parse("1.2 (300) 1")
+	})
+
+	type hex struct {
+		Value int `parse:"@Hex"`
+	}
+	hp, err := structs.Build[hex](
+		structs.NewBuilder().Token("Hex", parse.RegExp(`0x[0-9a-f]+`)),
+	)
+	as.NoError(err)
+	as.PanicsWithError(fmt.Sprintf(structs.ErrCannotCapture,
+		"0x1f", "Value", "structs_test.hex",
+	), func() {
+		hp.Parse("0x1f")
+	})
+}
+
+func TestBuildTokens(t *testing.T) {
+	as := assert.New(t)
+
+	type pair struct {
+		Key   string `parse:"@Key ':'"`
+		Value string `parse:"@'\\'quoted\\''"`
+	}
+
+	b := structs.NewBuilder().
+		Token("Key", parse.RegExp(`[a-z]+`)).
+		Elide(nil)
+	p, err := structs.Build[pair](b)
+	as.NoError(err)
+
+	s, f := p.Parse("abc:'quoted'")
+	as.Nil(f)
+	as.Equal(&pair{Key: "abc", Value: "'quoted'"}, s.Result)
+
+	s, f = p.Parse("abc : 'quoted'")
+	as.Nil(s)
+	as.NotNil(f)
+}
+
+func TestBuildErrors(t *testing.T) {
+	as := assert.New(t)
+	b := structs.NewBuilder()
+
+	type unknown struct {
+		Name string `parse:"@Missing"`
+	}
+	_, err := structs.Build[unknown](b)
+	as.EqualError(err, fmt.Sprintf(structs.ErrUnknownToken, "Missing"))
+
+	type recurse struct {
+		Name string `parse:"@@"`
+	}
+	_, err = structs.Build[recurse](b)
+	as.EqualError(err, fmt.Sprintf(structs.ErrCannotRecurse,
+		"Name", "structs_test.recurse",
+	))
+
+	type untagged struct {
+		Name string
+	}
+	_, err = structs.Build[untagged](b)
+	as.EqualError(err, fmt.Sprintf(structs.ErrNoFields,
+		"structs_test.untagged",
+	))
+
+	_, err = structs.Build[string](b)
+	as.EqualError(err, fmt.Sprintf(structs.ErrNotStruct, "string"))
+
+	type unexported struct {
+		Name string `parse:"@Ident"`
+		rest string `parse:"@Ident"`
+	}
+	_, err = structs.Build[unexported](b)
+	as.EqualError(err, fmt.Sprintf(structs.ErrUnexported,
+		"rest", "structs_test.unexported",
+	))
+
+	type invalid struct {
+		Name string `parse:"@(Ident"`
+	}
+	_, err = structs.Build[invalid](b)
+	as.Error(err)
+	as.Contains(err.Error(), "invalid parse tag on field Name")
+}
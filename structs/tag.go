@@ -0,0 +1,121 @@
+package structs
+
+import (
+	"strings"
+
+	"github.com/kode4food/kombi/parse"
+)
+
+type (
+	node = any
+
+	literal struct {
+		value string
+	}
+
+	token struct {
+		name string
+	}
+
+	self struct{}
+
+	capture struct {
+		node node
+	}
+
+	sequence []node
+
+	choice []node
+
+	repeat struct {
+		node node
+		op   string
+	}
+)
+
+var tagParser = makeTagParser()
+
+func makeTagParser() parse.Parser {
+	ws := parse.RegExp(`\s*`)
+
+	tok := func(p parse.Parser) parse.Parser {
+		return ws.Then(p)
+	}
+
+	var expr parse.Parser
+	exprRef := parse.Parser(func(i parse.Input) (*parse.Success, *parse.Failure) {
+		return expr(i)
+	})
+
+	lit := tok(parse.Or(
+		parse.RegExp(`'(\\.|[^'\\])*'`),
+		parse.RegExp(`"(\\.|[^"\\])*"`),
+	)).Map(func(r any) any {
+		s := r.(string)
+		return &literal{value: unescape(s[1 : len(s)-1])}
+	})
+
+	ident := tok(parse.RegExp(`[A-Za-z_][A-Za-z0-9_]*`)).Map(func(r any) any {
+		return &token{name: r.(string)}
+	})
+
+	atom := parse.Any(
+		ident,
+		lit,
+		exprRef.Enclosed(tok(parse.String("(")), tok(parse.String(")"))),
+	)
+
+	unary := parse.Any(
+		tok(parse.String("@@")).Return(&capture{node: &self{}}),
+		tok(parse.String("@")).Then(atom).Map(func(r any) any {
+			return &capture{node: r}
+		}),
+		atom,
+	)
+
+	term := unary.Bind(func(r any) parse.Parser {
+		return parse.Or(
+			tok(parse.RegExp(`[*+?]`)).Map(func(op any) any {
+				return &repeat{node: r, op: op.(string)}
+			}),
+			parse.Return(r),
+		)
+	})
+
+	seq := term.OneOrMore().Map(func(r any) any {
+		res := r.(parse.Results)
+		if len(res) == 1 {
+			return res[0]
+		}
+		return sequence(res)
+	})
+
+	expr = seq.Delimited(tok(parse.String("|"))).Map(func(r any) any {
+		res := r.(parse.Results)
+		if len(res) == 1 {
+			return res[0]
+		}
+		return choice(res)
+	})
+
+	return expr.Left(ws).Left(parse.EOF)
+}
+
+func parseTag(tag string) (node, error) {
+	s, f := tagParser.Parse(tag)
+	if f != nil {
+		return nil, f.Error
+	}
+	return s.Result, nil
+}
+
+func unescape(s string) string {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		buf.WriteByte(s[i])
+	}
+	return buf.String()
+}